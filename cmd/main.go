@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/srcabl/gateway/internal/boot"
-	"github.com/srcabl/services/pkg/config"
+	"github.com/srcabl/gateway/internal/config"
+	"go.uber.org/zap"
 )
 
 func main() {
@@ -23,18 +27,34 @@ func main() {
 		panic(err)
 	}
 
-	err = strap.Connect()
+	logger := strap.Logger
+	defer logger.Sync()
+
+	report, err := strap.Connect()
 	if err != nil {
 		panic(err)
 	}
-	defer func() {
-		errs := strap.Shutdown()
-		if errs != nil {
-			msg := "ERRORS ON SHUTDOWN:"
-			for e := range errs {
-				msg += fmt.Sprintf(" ---- %+v", e)
-			}
-			panic(msg)
+	logger.Info("started", zap.Any("components", report))
+
+	//wait for a termination signal or the server to fail
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case sig := <-sigs:
+		logger.Info("shutting down", zap.Stringer("signal", sig))
+	case err := <-strap.GraphServer.Errors():
+		logger.Error("server failed, shutting down", zap.Error(err))
+	}
+	signal.Stop(sigs)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Lifecycle.ShutdownTimeout)
+	defer cancel()
+	errs := strap.Shutdown(ctx)
+	if errs != nil {
+		msg := "ERRORS ON SHUTDOWN:"
+		for _, e := range errs {
+			msg += fmt.Sprintf(" ---- %+v", e)
 		}
-	}()
+		panic(msg)
+	}
 }
@@ -2,6 +2,18 @@
 
 package model
 
+type AccessToken struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	TokenType    string `json:"tokenType"`
+	ExpiresIn    int    `json:"expiresIn"`
+}
+
+type AccessTokenResponse struct {
+	Errors []*Error     `json:"errors"`
+	Token  *AccessToken `json:"token"`
+}
+
 type AuditFields struct {
 	CreatedAt int     `json:"createdAt"`
 	CreatedBy string  `json:"createdBy"`
@@ -19,14 +31,19 @@ type CommonErrorResponse struct {
 	Message *string `json:"message"`
 }
 
-type CommonPostResponse struct {
+type CommonLinkPreviewResponse struct {
+	Errors  []*Error     `json:"errors"`
+	Preview *LinkPreview `json:"preview"`
+}
+
+type CommonLinkResponse struct {
 	Errors []*Error     `json:"errors"`
-	Post   *PartialPost `json:"post"`
+	Link   *PartialLink `json:"link"`
 }
 
-type CommonPostsResponse struct {
-	Errors []*Error       `json:"errors"`
-	Posts  []*PartialPost `json:"posts"`
+type CommonPostResponse struct {
+	Errors []*Error     `json:"errors"`
+	Post   *PartialPost `json:"post"`
 }
 
 type CommonSourceResponse struct {
@@ -68,6 +85,10 @@ type FollowRequest struct {
 	FollowedID string `json:"followedID"`
 }
 
+type FollowersRequest struct {
+	UserID string `json:"userID"`
+}
+
 type FullPost struct {
 	Post     *PartialPost `json:"post"`
 	LinkID   string       `json:"linkID"`
@@ -79,22 +100,28 @@ type FullUser struct {
 	Details *UserDetails `json:"details"`
 }
 
+type LinkPreview struct {
+	URL     string           `json:"url"`
+	Known   bool             `json:"known"`
+	Link    *PartialLink     `json:"link"`
+	Sources []*PartialSource `json:"sources"`
+}
+
+type LinkRequest struct {
+	ID  *string `json:"id"`
+	URL *string `json:"url"`
+}
+
 type LoginUserRequest struct {
 	UsernameOrEmail string `json:"usernameOrEmail"`
 	Password        string `json:"password"`
 }
 
-type PartialPost struct {
-	ID      string `json:"id"`
-	UserID  string `json:"userID"`
-	LinkURL string `json:"linkURL"`
-	Comment string `json:"comment"`
-}
-
-type PartialSource struct {
-	ID           string `json:"id"`
-	Name         string `json:"name"`
-	Organization string `json:"organization"`
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
 }
 
 type PartialUser struct {
@@ -103,6 +130,17 @@ type PartialUser struct {
 	Username string `json:"username"`
 }
 
+type PostConnection struct {
+	Errors   []*Error    `json:"errors"`
+	Edges    []*PostEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+}
+
+type PostEdge struct {
+	Cursor string       `json:"cursor"`
+	Node   *PartialPost `json:"node"`
+}
+
 type PostsRequest struct {
 	UserID string `json:"userID"`
 }
@@ -113,7 +151,29 @@ type RegisterUserRequest struct {
 	Password string `json:"password"`
 }
 
+type SourceEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type SourceGraph struct {
+	Errors    []*Error      `json:"errors"`
+	Nodes     []*SourceNode `json:"nodes"`
+	Edges     []*SourceEdge `json:"edges"`
+	Truncated bool          `json:"truncated"`
+}
+
+type SourceNode struct {
+	Source *PartialSource `json:"source"`
+	Depth  int            `json:"depth"`
+}
+
+type SourceRequest struct {
+	ID string `json:"id"`
+}
+
 type UpdatePostRequest struct {
+	PostID  string `json:"postID"`
 	Title   string `json:"title"`
 	Comment string `json:"comment"`
 	URL     string `json:"url"`
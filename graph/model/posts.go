@@ -1,18 +1,64 @@
 package model
 
 import (
-	"fmt"
+	"sort"
 
 	"github.com/gofrs/uuid"
+	"github.com/srcabl/gateway/internal/pagination"
 	postspb "github.com/srcabl/protos/posts"
 	sharedpb "github.com/srcabl/protos/shared"
 	sourcespb "github.com/srcabl/protos/sources"
 )
 
+// PartialPost is a graphql partial post. Its author, link and sources are
+// resolved on demand from the ids it carries.
+type PartialPost struct {
+	ID      string `json:"id"`
+	UserID  string `json:"userID"`
+	LinkURL string `json:"linkURL"`
+	Comment string `json:"comment"`
+	// LinkID is the id of the posted link
+	LinkID string `json:"-"`
+}
+
+// PartialLink is a graphql partial link
+type PartialLink struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// SourceIDs are the ids of the sources at the head of the link's provenance
+	SourceIDs []string `json:"-"`
+}
+
 func GetLinkByURLRequest(input CreatePostRequest) *postspb.GetLinkRequest {
 	return &postspb.GetLinkRequest{Url: input.URL, GetBy: postspb.GetLinkRequest_URL}
 }
 
+// LinkRequestToPBGetLinkRequest converts a link url to a grpc get link request
+func LinkRequestToPBGetLinkRequest(url string) *postspb.GetLinkRequest {
+	return &postspb.GetLinkRequest{Url: url, GetBy: postspb.GetLinkRequest_URL}
+}
+
+// PBGetLinkResponseToCommonLinkResponse converts a grpc get link response to a graphql common link response
+func PBGetLinkResponseToCommonLinkResponse(res *postspb.GetLinkResponse, resErr error) *CommonLinkResponse {
+	var errors []*Error
+	var link *PartialLink
+	if resErr != nil {
+		errors = append(errors, PBResponseErrorToError(resErr))
+	}
+	if res.GetLink() != nil {
+		partlink, linkErr := PBLinkToPartialLink(res.GetLink())
+		if linkErr != nil {
+			errors = append(errors, linkErr)
+		} else {
+			link = partlink
+		}
+	}
+	return &CommonLinkResponse{
+		Errors: errors,
+		Link:   link,
+	}
+}
+
 // CreatePostRequestToPBDetermineSourceRequest converts a graphql create post request to a grpc determine post source request
 func CreatePostRequestToPBDetermineSourceRequest(input CreatePostRequest) *sourcespb.DetermineLinkSourceRequest {
 	return &sourcespb.DetermineLinkSourceRequest{Url: input.URL}
@@ -40,24 +86,57 @@ func CreatePostRequestToPBCreatePostRequest(input CreatePostRequest, userID, lin
 	}
 }
 
+// UpdatePostRequestToPBUpdatePostRequest converts a graphql update post request to a grpc update post request
+func UpdatePostRequestToPBUpdatePostRequest(input UpdatePostRequest, postID, linkID []byte) *postspb.UpdatePostRequest {
+	return &postspb.UpdatePostRequest{
+		Uuid:     postID,
+		LinkUuid: linkID,
+		Title:    input.Title,
+		Comment:  input.Comment,
+	}
+}
+
+// PostIDToPBGetPostRequest converts a post id to a grpc get post request
+func PostIDToPBGetPostRequest(postID []byte) *postspb.GetPostRequest {
+	return &postspb.GetPostRequest{Uuid: postID}
+}
+
+// DeletePostRequestToPBDeletePostRequest converts a graphql delete post request to a grpc delete post request
+func DeletePostRequestToPBDeletePostRequest(postID []byte) *postspb.DeletePostRequest {
+	return &postspb.DeletePostRequest{Uuid: postID}
+}
+
 // PBCreatePostLinkResponseToCommonPostResponse converts a grpc create post response to a common post response
 func PBCreatePostLinkResponseToCommonPostResponse(postRes *postspb.CreatePostResponse, link *sharedpb.Link, resErr error) *CommonPostResponse {
-	fmt.Println("transforming")
+	return postResponseToCommonPostResponse(postRes, link, resErr)
+}
+
+// PBUpdatePostLinkResponseToCommonPostResponse converts a grpc update post response to a common post response
+func PBUpdatePostLinkResponseToCommonPostResponse(postRes *postspb.UpdatePostResponse, link *sharedpb.Link, resErr error) *CommonPostResponse {
+	return postResponseToCommonPostResponse(postRes, link, resErr)
+}
+
+// PBGetPostLinkResponseToCommonPostResponse converts a grpc get post response to a common post response
+func PBGetPostLinkResponseToCommonPostResponse(postRes *postspb.GetPostResponse, link *sharedpb.Link, resErr error) *CommonPostResponse {
+	return postResponseToCommonPostResponse(postRes, link, resErr)
+}
+
+type postGetter interface {
+	GetPost() *sharedpb.Post
+}
+
+func postResponseToCommonPostResponse(pg postGetter, link *sharedpb.Link, resErr error) *CommonPostResponse {
 	var errors []*Error
 	var post *PartialPost
 	if resErr != nil {
-		fmt.Println("making the error")
 		errors = append(errors, PBResponseErrorToError(resErr))
 	}
 
-	if postRes.Post != nil {
-		fmt.Println("transoforming user")
-		partuser, userErr := PBPostToPartialPost(postRes.Post, link)
+	if pg.GetPost() != nil {
+		partuser, userErr := PBPostToPartialPost(pg.GetPost(), link)
 		if userErr != nil {
-			fmt.Println("error while transoforming user")
 			errors = append(errors, userErr)
 		} else {
-			fmt.Println("setting transoforming user")
 			post = partuser
 		}
 	}
@@ -88,10 +167,176 @@ func PBPostToPartialPost(post *sharedpb.Post, link *sharedpb.Link) (*PartialPost
 			Message: &message,
 		}
 	}
+	linkUUID, err := uuid.FromBytes(post.LinkUuid)
+	if err != nil {
+		field := "linkID"
+		message := err.Error()
+		return nil, &Error{
+			Field:   &field,
+			Message: &message,
+		}
+	}
 	return &PartialPost{
 		ID:      postUUID.String(),
 		UserID:  userUUID.String(),
-		LinkURL: link.Url,
+		LinkURL: link.GetUrl(),
 		Comment: post.Comment.PrimaryContent,
+		LinkID:  linkUUID.String(),
 	}, nil
 }
+
+// PBLinkToPartialLink converts a grpc link to a graphql partial link
+func PBLinkToPartialLink(link *sharedpb.Link) (*PartialLink, *Error) {
+	if link == nil {
+		return nil, NewFieldError("link", "link is missing from the response")
+	}
+	linkUUID, err := uuid.FromBytes(link.Uuid)
+	if err != nil {
+		field := "ID"
+		message := err.Error()
+		return nil, &Error{
+			Field:   &field,
+			Message: &message,
+		}
+	}
+	sourceIDs := make([]string, 0, len(link.SourceHeadUuids))
+	for _, id := range link.SourceHeadUuids {
+		sourceUUID, err := uuid.FromBytes(id)
+		if err != nil {
+			field := "sources"
+			message := err.Error()
+			return nil, &Error{
+				Field:   &field,
+				Message: &message,
+			}
+		}
+		sourceIDs = append(sourceIDs, sourceUUID.String())
+	}
+	return &PartialLink{
+		ID:        linkUUID.String(),
+		URL:       link.Url,
+		SourceIDs: sourceIDs,
+	}, nil
+}
+
+// LinkUUIDsToPBGetLinksRequest converts a list of link uuids to a grpc get links request
+func LinkUUIDsToPBGetLinksRequest(linkUUIDs [][]byte) *postspb.GetLinksRequest {
+	return &postspb.GetLinksRequest{
+		Uuids: linkUUIDs,
+	}
+}
+
+// PageToPBListUsersPostsRequest converts a page of a user's posts to a grpc list users posts request
+func PageToPBListUsersPostsRequest(userID []byte, page pagination.Page) *postspb.ListUsersPostsRequest {
+	return &postspb.ListUsersPostsRequest{
+		UserUuid: userID,
+		Limit:    int32(page.Limit),
+		After:    keyToPBPostSortKey(page.After),
+		Before:   keyToPBPostSortKey(page.Before),
+		FromEnd:  page.FromEnd,
+	}
+}
+
+// PageToPBListSourcesPostsRequest converts a page of a source's posts to a grpc list sources posts request
+func PageToPBListSourcesPostsRequest(sourceID []byte, page pagination.Page) *postspb.ListSourcesPostsRequest {
+	return &postspb.ListSourcesPostsRequest{
+		SourceUuid: sourceID,
+		Limit:      int32(page.Limit),
+		After:      keyToPBPostSortKey(page.After),
+		Before:     keyToPBPostSortKey(page.Before),
+		FromEnd:    page.FromEnd,
+	}
+}
+
+func keyToPBPostSortKey(key *pagination.Key) *postspb.PostSortKey {
+	if key == nil {
+		return nil
+	}
+	return &postspb.PostSortKey{
+		CreatedAt: key.CreatedAt,
+		Uuid:      key.UUID,
+	}
+}
+
+// PBPostKey gets the pagination key of a grpc post
+func PBPostKey(post *sharedpb.Post) pagination.Key {
+	return pagination.Key{CreatedAt: post.CreatedAt, UUID: post.Uuid}
+}
+
+// PBListUsersPostsResponseToPostConnection converts a grpc list users posts response to a graphql post connection
+func PBListUsersPostsResponseToPostConnection(res *postspb.ListUsersPostsResponse, page pagination.Page) *PostConnection {
+	return PBPostsToPostConnection(res.Posts, res.Links, page, res.HasMore)
+}
+
+// PBPostsToPostConnection converts a page of grpc posts and their links to a graphql post connection
+func PBPostsToPostConnection(pbPosts []*sharedpb.Post, pbLinks []*sharedpb.Link, page pagination.Page, hasMore bool) *PostConnection {
+	return pbPostsToPostConnection(pbPosts, pbLinks, page, hasMore, func(p *sharedpb.Post) string {
+		return PBPostKey(p).Cursor()
+	})
+}
+
+// PBFeedPostsToPostConnection converts a page of merged grpc posts to a graphql post connection.
+// Each cursor also carries the links shown up to its post, starting from those already seen
+// and keeping at most maxSeen of the latest, so paging on from it does not show them again.
+func PBFeedPostsToPostConnection(pbPosts []*sharedpb.Post, pbLinks []*sharedpb.Link, page pagination.Page, hasMore bool, seen []string, maxSeen int) *PostConnection {
+	seen = append([]string(nil), seen...)
+	return pbPostsToPostConnection(pbPosts, pbLinks, page, hasMore, func(p *sharedpb.Post) string {
+		seen = append(seen, pagination.SeenLink(p.LinkUuid))
+		if len(seen) > maxSeen {
+			seen = seen[len(seen)-maxSeen:]
+		}
+		key := PBPostKey(p)
+		key.Seen = append([]string(nil), seen...)
+		return key.Cursor()
+	})
+}
+
+func pbPostsToPostConnection(pbPosts []*sharedpb.Post, pbLinks []*sharedpb.Link, page pagination.Page, hasMore bool, cursor func(*sharedpb.Post) string) *PostConnection {
+	// match links to posts by id rather than relying on the order they come back in
+	links := make(map[string]*sharedpb.Link, len(pbLinks))
+	for _, l := range pbLinks {
+		links[string(l.Uuid)] = l
+	}
+	posts := make([]*sharedpb.Post, len(pbPosts))
+	copy(posts, pbPosts)
+	SortPBPosts(posts)
+	conn := &PostConnection{
+		Edges: []*PostEdge{},
+		PageInfo: &PageInfo{
+			HasNextPage:     page.HasNextPage(hasMore),
+			HasPreviousPage: page.HasPreviousPage(hasMore),
+		},
+	}
+	for _, p := range posts {
+		post, postErr := PBPostToPartialPost(p, links[string(p.LinkUuid)])
+		if postErr != nil {
+			conn.Errors = append(conn.Errors, postErr)
+			continue
+		}
+		conn.Edges = append(conn.Edges, &PostEdge{
+			Cursor: cursor(p),
+			Node:   post,
+		})
+	}
+	if len(conn.Edges) > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[len(conn.Edges)-1].Cursor
+	}
+	return conn
+}
+
+// SortPBPosts sorts grpc posts into the post order, newest first
+func SortPBPosts(posts []*sharedpb.Post) {
+	sort.SliceStable(posts, func(i, j int) bool {
+		return PBPostKey(posts[i]).Before(PBPostKey(posts[j]))
+	})
+}
+
+// ErrorToPostConnection converts an error to an empty graphql post connection carrying it
+func ErrorToPostConnection(err *Error) *PostConnection {
+	return &PostConnection{
+		Errors:   []*Error{err},
+		Edges:    []*PostEdge{},
+		PageInfo: &PageInfo{},
+	}
+}
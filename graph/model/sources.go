@@ -0,0 +1,95 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+	sharedpb "github.com/srcabl/protos/shared"
+	sourcespb "github.com/srcabl/protos/sources"
+)
+
+// PartialSource is a graphql partial source. The sources it got its stories
+// from are resolved on demand from the ids it carries.
+type PartialSource struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Organization string `json:"organization"`
+	// ParentIDs are the ids of the sources this source got its stories from
+	ParentIDs []string `json:"-"`
+}
+
+// SourceUUIDsToPBGetSourcesRequest converts a list of source uuids to a grpc get sources request
+func SourceUUIDsToPBGetSourcesRequest(sourceUUIDs [][]byte) *sourcespb.GetSourcesRequest {
+	return &sourcespb.GetSourcesRequest{
+		Uuids: sourceUUIDs,
+	}
+}
+
+// PBGetSourcesResponseToCommonSourcesResponse converts a grpc get sources response to a graphql common sources response
+func PBGetSourcesResponseToCommonSourcesResponse(res *sourcespb.GetSourcesResponse, resErr error) *CommonSourcesResponse {
+	var errors []*Error
+	var sources []*PartialSource
+	if resErr != nil {
+		errors = append(errors, PBResponseErrorToError(resErr))
+	}
+	for _, s := range res.GetSources() {
+		partsource, sourceErr := PBSourceToPartialSource(s)
+		if sourceErr != nil {
+			errors = append(errors, sourceErr)
+			continue
+		}
+		sources = append(sources, partsource)
+	}
+	return &CommonSourcesResponse{
+		Errors:  errors,
+		Sources: sources,
+	}
+}
+
+// PBSourceToPartialSource converts a grpc source to a graphql partial source
+func PBSourceToPartialSource(source *sharedpb.Source) (*PartialSource, *Error) {
+	sourceUUID, err := uuid.FromBytes(source.Uuid)
+	if err != nil {
+		field := "ID"
+		message := err.Error()
+		return nil, &Error{
+			Field:   &field,
+			Message: &message,
+		}
+	}
+	parentIDs := make([]string, 0, len(source.ParentUuids))
+	for _, id := range source.ParentUuids {
+		parentUUID, err := uuid.FromBytes(id)
+		if err != nil {
+			field := "sources"
+			message := err.Error()
+			return nil, &Error{
+				Field:   &field,
+				Message: &message,
+			}
+		}
+		parentIDs = append(parentIDs, parentUUID.String())
+	}
+	return &PartialSource{
+		ID:           sourceUUID.String(),
+		Name:         source.Name,
+		Organization: source.Organization,
+		ParentIDs:    parentIDs,
+	}, nil
+}
+
+// PBSourceNodesToPartialSources converts grpc source nodes to graphql partial sources
+func PBSourceNodesToPartialSources(nodes []*sharedpb.SourceNode) ([]*PartialSource, []*Error) {
+	var errors []*Error
+	var sources []*PartialSource
+	for _, n := range nodes {
+		if n.GetSource() == nil {
+			continue
+		}
+		partsource, sourceErr := PBSourceToPartialSource(n.Source)
+		if sourceErr != nil {
+			errors = append(errors, sourceErr)
+			continue
+		}
+		sources = append(sources, partsource)
+	}
+	return sources, errors
+}
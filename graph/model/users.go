@@ -1,9 +1,12 @@
 package model
 
 import (
-	"fmt"
+	"crypto/rand"
 
 	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/oidc"
+	"github.com/srcabl/gateway/internal/token"
 	"github.com/srcabl/gateway/internal/util"
 	sharedpb "github.com/srcabl/protos/shared"
 	"github.com/srcabl/protos/users"
@@ -39,6 +42,32 @@ func ValidateRegisterAndRegisterUserRequestToPBCreateUserRequest(input RegisterU
 	}, nil
 }
 
+// ValidateChangePasswordAndChangePasswordRequestToPBUpdatePasswordRequest converts a graphql change password request to a grpc update password request
+func ValidateChangePasswordAndChangePasswordRequestToPBUpdatePasswordRequest(input ChangePasswordRequest, userUUID []byte) (*userspb.UpdatePasswordRequest, []*Error) {
+	if isvalid, message := util.ValidateMinPasswordRequirements(input.NewPassword); !isvalid {
+		field := "newPassword"
+		return nil, []*Error{{Field: &field, Message: &message}}
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.NewPassword), bcrypt.MinCost)
+	if err != nil {
+		field := "newPassword"
+		message := "password is not valid"
+		return nil, []*Error{{Field: &field, Message: &message}}
+	}
+	return &userspb.UpdatePasswordRequest{
+		Uuid:           userUUID,
+		HashedPassword: string(hash),
+	}, nil
+}
+
+// EmailToPBGetUserRequest converts an email to a grpc get user request
+func EmailToPBGetUserRequest(email string) *userspb.GetUserRequest {
+	return &userspb.GetUserRequest{
+		Email: email,
+		GetBy: userspb.GetUserRequest_EMAIL,
+	}
+}
+
 // CurrentUserRequestToPBGetUserRequest converts a graphql current user request to a grpc get user request
 func CurrentUserRequestToPBGetUserRequest(userUUID []byte) *userspb.GetUserRequest {
 	return &users.GetUserRequest{
@@ -65,6 +94,27 @@ func ToPBFollowRequest(followerUUID, followedUUID []byte, followType userspb.Fol
 	}
 }
 
+// UserUUIDToPBListFollowedUsersRequest converts a user uuid to a grpc list followed users request
+func UserUUIDToPBListFollowedUsersRequest(userUUID []byte) *userspb.ListFollowedUsersRequest {
+	return &userspb.ListFollowedUsersRequest{
+		UserUuid: userUUID,
+	}
+}
+
+// UserUUIDToPBListFollowedSourcesRequest converts a user uuid to a grpc list followed sources request
+func UserUUIDToPBListFollowedSourcesRequest(userUUID []byte) *userspb.ListFollowedSourcesRequest {
+	return &userspb.ListFollowedSourcesRequest{
+		UserUuid: userUUID,
+	}
+}
+
+// UserUUIDToPBListFollowersRequest converts a user uuid to a grpc list followers request
+func UserUUIDToPBListFollowersRequest(userUUID []byte) *userspb.ListFollowersRequest {
+	return &userspb.ListFollowersRequest{
+		UserUuid: userUUID,
+	}
+}
+
 func determineValidateByFields(usernameOrEmail string) (string, string, userspb.ValidateUserCredentialsRequest_ValidateUserBy) {
 	if isvalid, _ := util.ValidateEmailRequirements(usernameOrEmail); isvalid {
 		return "", usernameOrEmail, users.ValidateUserCredentialsRequest_EMAIL
@@ -87,27 +137,27 @@ func PBValidateUserResponseToCommonUserResponse(res *userspb.ValidateUserCredent
 	return userResponseToCommonUserResponse(res, resErr)
 }
 
+// PBUpdatePasswordResponseToCommonUserResponse converts a grpc update password response to a graphql common user response
+func PBUpdatePasswordResponseToCommonUserResponse(res *userspb.UpdatePasswordResponse, resErr error) *CommonUserResponse {
+	return userResponseToCommonUserResponse(res, resErr)
+}
+
 type userGetter interface {
 	GetUser() *sharedpb.User
 }
 
 func userResponseToCommonUserResponse(ug userGetter, resErr error) *CommonUserResponse {
-	fmt.Println("transforming")
 	var errors []*Error
 	var user *PartialUser
 	if resErr != nil {
-		fmt.Println("making the error")
 		errors = append(errors, PBResponseErrorToError(resErr))
 	}
 
 	if ug.GetUser() != nil {
-		fmt.Println("transoforming user")
 		partuser, userErr := PBUserToPartialUser(ug.GetUser())
 		if userErr != nil {
-			fmt.Println("error while transoforming user")
 			errors = append(errors, userErr)
 		} else {
-			fmt.Println("setting transoforming user")
 			user = partuser
 		}
 	}
@@ -118,6 +168,40 @@ func userResponseToCommonUserResponse(ug userGetter, resErr error) *CommonUserRe
 	}
 }
 
+// PBListFollowedUsersResponseToCommonUsersResponse converts a grpc list followed users response to a graphql common users response
+func PBListFollowedUsersResponseToCommonUsersResponse(res *userspb.ListFollowedUsersResponse, resErr error) *CommonUsersResponse {
+	return usersResponseToCommonUsersResponse(res, resErr)
+}
+
+// PBListFollowersResponseToCommonUsersResponse converts a grpc list followers response to a graphql common users response
+func PBListFollowersResponseToCommonUsersResponse(res *userspb.ListFollowersResponse, resErr error) *CommonUsersResponse {
+	return usersResponseToCommonUsersResponse(res, resErr)
+}
+
+type usersGetter interface {
+	GetUsers() []*sharedpb.User
+}
+
+func usersResponseToCommonUsersResponse(ug usersGetter, resErr error) *CommonUsersResponse {
+	var errors []*Error
+	var users []*PartialUser
+	if resErr != nil {
+		errors = append(errors, PBResponseErrorToError(resErr))
+	}
+	for _, u := range ug.GetUsers() {
+		partuser, userErr := PBUserToPartialUser(u)
+		if userErr != nil {
+			errors = append(errors, userErr)
+			continue
+		}
+		users = append(users, partuser)
+	}
+	return &CommonUsersResponse{
+		Errors: errors,
+		User:   users,
+	}
+}
+
 // PBUserToPartialUser converts a grpc user to a graphql partial user
 func PBUserToPartialUser(user *sharedpb.User) (*PartialUser, *Error) {
 	uuid, err := uuid.FromBytes(user.Uuid)
@@ -146,3 +230,79 @@ func PBResponseErrorToError(err error) *Error {
 		Message: &message,
 	}
 }
+
+// NewFieldError creates a graphql error for the given field
+func NewFieldError(field, message string) *Error {
+	return &Error{
+		Field:   &field,
+		Message: &message,
+	}
+}
+
+// UserUUIDsToPBGetUsersRequest converts a list of user uuids to a grpc get users request
+func UserUUIDsToPBGetUsersRequest(userUUIDs [][]byte) *userspb.GetUsersRequest {
+	return &userspb.GetUsersRequest{
+		Uuids: userUUIDs,
+	}
+}
+
+// TokenPairToAccessTokenResponse converts issued bearer tokens to a graphql access token response
+func TokenPairToAccessTokenResponse(pair *token.Pair) *AccessTokenResponse {
+	return &AccessTokenResponse{
+		Token: &AccessToken{
+			AccessToken:  pair.Access,
+			RefreshToken: pair.Refresh,
+			TokenType:    token.TypeBearer,
+			ExpiresIn:    int(pair.ExpiresIn.Seconds()),
+		},
+	}
+}
+
+// IdentityToPBGetUserByIdentityRequest converts a provider identity to a grpc get user by identity request
+func IdentityToPBGetUserByIdentityRequest(identity oidc.Identity) *userspb.GetUserByIdentityRequest {
+	return &userspb.GetUserByIdentityRequest{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+	}
+}
+
+// IdentityToPBLinkIdentityRequest converts a provider identity to a grpc link identity request for the user
+func IdentityToPBLinkIdentityRequest(userUUID []byte, identity oidc.Identity) *userspb.LinkIdentityRequest {
+	return &userspb.LinkIdentityRequest{
+		UserUuid: userUUID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}
+}
+
+// IdentityToPBCreateUserRequest converts a provider identity to a grpc create user request. The
+// user gets a random password nobody knows, which they can replace through forgot password.
+func IdentityToPBCreateUserRequest(identity oidc.Identity, username string) (*userspb.CreateUserRequest, error) {
+	if isvalid, message := util.ValidateUsernameRequirements(username); !isvalid {
+		return nil, errors.New(message)
+	}
+	// an email the provider has not verified could belong to anyone, so the user is left to add one
+	email := ""
+	if identity.EmailVerified {
+		email = identity.Email
+	}
+	if email != "" {
+		if isvalid, message := util.ValidateEmailRequirements(email); !isvalid {
+			return nil, errors.New(message)
+		}
+	}
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return nil, errors.Wrap(err, "failed to generate password")
+	}
+	hash, err := bcrypt.GenerateFromPassword(password, bcrypt.MinCost)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash password")
+	}
+	return &userspb.CreateUserRequest{
+		Username:        username,
+		Email:           email,
+		HashedPasssword: string(hash),
+	}, nil
+}
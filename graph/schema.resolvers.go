@@ -5,10 +5,10 @@ package graph
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/srcabl/gateway/graph/generated"
 	"github.com/srcabl/gateway/graph/model"
+	"github.com/srcabl/gateway/internal/pagination"
 )
 
 func (r *mutationResolver) ChangePassword(ctx context.Context, input model.ChangePasswordRequest) (*model.CommonUserResponse, error) {
@@ -31,6 +31,14 @@ func (r *mutationResolver) Logout(ctx context.Context) (bool, error) {
 	return r.usersClient.Logout(ctx)
 }
 
+func (r *mutationResolver) CreateAccessToken(ctx context.Context) (*model.AccessTokenResponse, error) {
+	return r.usersClient.CreateAccessToken(ctx)
+}
+
+func (r *mutationResolver) RefreshAccessToken(ctx context.Context, refreshToken string) (*model.AccessTokenResponse, error) {
+	return r.usersClient.RefreshAccessToken(ctx, refreshToken)
+}
+
 func (r *mutationResolver) FollowUser(ctx context.Context, input model.FollowRequest) (bool, error) {
 	return r.usersClient.FollowUser(ctx, input)
 }
@@ -52,38 +60,110 @@ func (r *mutationResolver) CreatePost(ctx context.Context, input model.CreatePos
 }
 
 func (r *mutationResolver) UpdatePost(ctx context.Context, input model.UpdatePostRequest) (*model.CommonPostResponse, error) {
-	panic(fmt.Errorf("not implemented"))
+	return r.postsClient.UpdatePost(ctx, input)
 }
 
 func (r *mutationResolver) DeletePost(ctx context.Context, input model.DeletePostRequest) (*model.CommonPostResponse, error) {
-	panic(fmt.Errorf("not implemented"))
+	return r.postsClient.DeletePost(ctx, input)
+}
+
+func (r *partialLinkResolver) Sources(ctx context.Context, obj *model.PartialLink, depth *int) (*model.SourceGraph, error) {
+	return r.sourcesClient.SourceGraph(ctx, obj.SourceIDs, depth)
+}
+
+func (r *partialPostResolver) Author(ctx context.Context, obj *model.PartialPost) (*model.PartialUser, error) {
+	return r.usersClient.UserByID(ctx, obj.UserID)
+}
+
+func (r *partialPostResolver) Link(ctx context.Context, obj *model.PartialPost) (*model.PartialLink, error) {
+	return r.postsClient.LinkByID(ctx, obj.LinkID)
+}
+
+func (r *partialPostResolver) Sources(ctx context.Context, obj *model.PartialPost, depth *int) (*model.SourceGraph, error) {
+	return r.postsClient.LinkSourceGraph(ctx, obj.LinkID, depth)
+}
+
+func (r *partialSourceResolver) Sources(ctx context.Context, obj *model.PartialSource, depth *int) (*model.SourceGraph, error) {
+	return r.sourcesClient.SourceGraph(ctx, obj.ParentIDs, depth)
 }
 
 func (r *queryResolver) CurrentUser(ctx context.Context) (*model.CommonUserResponse, error) {
 	return r.usersClient.CurrentUser(ctx)
 }
 
-func (r *queryResolver) CurrentUserUsersFollowed(ctx context.Context) (*model.CommonUserResponse, error) {
-	panic(fmt.Errorf("not implemented"))
+func (r *queryResolver) CSRFToken(ctx context.Context) (string, error) {
+	return r.usersClient.CSRFToken(ctx)
+}
+
+func (r *queryResolver) CurrentUserUsersFollowed(ctx context.Context) (*model.CommonUsersResponse, error) {
+	return r.usersClient.CurrentUserUsersFollowed(ctx)
+}
+
+func (r *queryResolver) CurrentUserSourcesFollowed(ctx context.Context) (*model.CommonSourcesResponse, error) {
+	return r.usersClient.CurrentUserSourcesFollowed(ctx)
 }
 
-func (r *queryResolver) CurrentUserSourcesFollowed(ctx context.Context) (*model.CommonSourceResponse, error) {
-	panic(fmt.Errorf("not implemented"))
+func (r *queryResolver) Followers(ctx context.Context, input model.FollowersRequest) (*model.CommonUsersResponse, error) {
+	return r.usersClient.Followers(ctx, input)
 }
 
-func (r *queryResolver) CurrentUsersPosts(ctx context.Context) (*model.CommonPostsResponse, error) {
-	return r.postsClient.CurrentUsersPosts(ctx)
+func (r *queryResolver) CurrentUsersPosts(ctx context.Context, first *int, after *string, last *int, before *string) (*model.PostConnection, error) {
+	return r.postsClient.CurrentUsersPosts(ctx, pagination.Args{First: first, After: after, Last: last, Before: before})
 }
 
-func (r *queryResolver) Posts(ctx context.Context, input model.PostsRequest) (*model.CommonPostsResponse, error) {
-	return r.postsClient.Posts(ctx, input)
+func (r *queryResolver) Posts(ctx context.Context, input model.PostsRequest, first *int, after *string, last *int, before *string) (*model.PostConnection, error) {
+	return r.postsClient.Posts(ctx, input, pagination.Args{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *queryResolver) Feed(ctx context.Context, first *int, after *string, last *int, before *string) (*model.PostConnection, error) {
+	return r.postsClient.Feed(ctx, pagination.Args{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *queryResolver) Link(ctx context.Context, input model.LinkRequest) (*model.CommonLinkResponse, error) {
+	return r.postsClient.Link(ctx, input)
+}
+
+func (r *queryResolver) PreviewLink(ctx context.Context, url string) (*model.CommonLinkPreviewResponse, error) {
+	return r.postsClient.PreviewLink(ctx, url)
+}
+
+func (r *queryResolver) Source(ctx context.Context, input model.SourceRequest) (*model.CommonSourceResponse, error) {
+	return r.sourcesClient.Source(ctx, input)
+}
+
+func (r *subscriptionResolver) PostCreated(ctx context.Context, userID string) (<-chan *model.PartialPost, error) {
+	return r.postsClient.PostCreated(ctx, userID)
+}
+
+func (r *subscriptionResolver) FeedUpdated(ctx context.Context) (<-chan *model.PartialPost, error) {
+	return r.postsClient.FeedUpdated(ctx)
+}
+
+func (r *subscriptionResolver) PostDeleted(ctx context.Context) (<-chan *model.PartialPost, error) {
+	return r.postsClient.PostDeleted(ctx)
 }
 
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
+// PartialLink returns generated.PartialLinkResolver implementation.
+func (r *Resolver) PartialLink() generated.PartialLinkResolver { return &partialLinkResolver{r} }
+
+// PartialPost returns generated.PartialPostResolver implementation.
+func (r *Resolver) PartialPost() generated.PartialPostResolver { return &partialPostResolver{r} }
+
+// PartialSource returns generated.PartialSourceResolver implementation.
+func (r *Resolver) PartialSource() generated.PartialSourceResolver { return &partialSourceResolver{r} }
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 type mutationResolver struct{ *Resolver }
+type partialLinkResolver struct{ *Resolver }
+type partialPostResolver struct{ *Resolver }
+type partialSourceResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
@@ -1,83 +1,159 @@
 package boot
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/lifecycle"
+	"github.com/srcabl/gateway/internal/logging"
+	"github.com/srcabl/gateway/internal/mail"
+	"github.com/srcabl/gateway/internal/metrics"
+	"github.com/srcabl/gateway/internal/pubsub"
 	"github.com/srcabl/gateway/internal/server"
 	"github.com/srcabl/gateway/internal/services"
-	"github.com/srcabl/services/pkg/config"
+	"github.com/srcabl/gateway/internal/signed"
+	"github.com/srcabl/gateway/internal/token"
+	"github.com/srcabl/gateway/internal/tracing"
+	"google.golang.org/grpc"
 )
 
 // Strap initializes the gateway service
 type Strap struct {
 	Config        *config.Gateway
+	Logger        *logging.Logger
 	UsersClient   services.UsersClient
 	PostsClient   services.PostsClient
 	SourcesClient services.SourcesClient
 	GraphServer   server.GraphQL
 
-	onconnect  map[string](func() (func() error, error))
-	onshutdown map[string](func() error)
+	registry *lifecycle.Registry
 }
 
 // New news up a boot strap
 func New(cfg *config.Gateway) (*Strap, error) {
-	usersClient, err := services.NewUsersClient(cfg)
+	logger, err := logging.New(cfg.Logging)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to new up users client")
+		return nil, errors.Wrap(err, "failed to new up logger")
 	}
 
-	sourcesClient, err := services.NewSourcesClient(cfg)
+	traces, err := tracing.New(cfg.Tracing)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up tracing")
+	}
+
+	metrics := metrics.New()
+	dialer := services.NewDialer(
+		logger,
+		grpc.WithChainUnaryInterceptor(
+			services.RequestIDUnaryClientInterceptor(),
+			tracing.UnaryClientInterceptor(),
+			metrics.UnaryClientInterceptor(),
+		),
+		grpc.WithChainStreamInterceptor(
+			services.RequestIDStreamClientInterceptor(),
+			tracing.StreamClientInterceptor(),
+			metrics.StreamClientInterceptor(),
+		),
+	)
+
+	sourcesClient, err := services.NewSourcesClient(cfg, logger, dialer)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to new up sources client")
 	}
 
-	postsClient, err := services.NewPostsClient(cfg, sourcesClient)
+	mailer, err := mail.New(cfg.Mailer, logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up mailer")
+	}
+
+	refreshStore, err := signed.NewStore(cfg.Tokens.Store, cfg.Tokens.Dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up refresh token store")
+	}
+
+	tokens, err := token.NewTokens(cfg.Tokens, refreshStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up bearer tokens")
+	}
+
+	usersClient, err := services.NewUsersClient(cfg, logger, dialer, sourcesClient, mailer, tokens)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up users client")
+	}
+
+	bus := pubsub.NewMemoryBus(cfg.Subscriptions.BufferSize)
+
+	postsClient, err := services.NewPostsClient(cfg, logger, dialer, sourcesClient, usersClient, bus)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to new up posts client")
 	}
 
-	server, err := server.New(cfg, usersClient, postsClient, sourcesClient)
+	server, err := server.New(cfg, logger, metrics, tokens, usersClient, postsClient, sourcesClient)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to new up the graph ql server")
 	}
 
+	registry := lifecycle.NewRegistry(cfg.Lifecycle.StartTimeout)
+	registry.Register(lifecycle.Component{
+		Name: "tracing",
+		Run:  traces.Run,
+	})
+	registry.Register(lifecycle.Component{
+		Name:      "sources client",
+		DependsOn: []string{"tracing"},
+		Run:       sourcesClient.Run,
+	})
+	registry.Register(lifecycle.Component{
+		Name:      "users client",
+		DependsOn: []string{"tracing", "sources client"},
+		Run:       usersClient.Run,
+	})
+	registry.Register(lifecycle.Component{
+		Name: "pubsub",
+		// closing the bus ends the subscriptions still open once the server has stopped
+		Run: func() (func(context.Context) error, error) {
+			return func(context.Context) error { return bus.Close() }, nil
+		},
+	})
+	registry.Register(lifecycle.Component{
+		Name:      "posts client",
+		DependsOn: []string{"tracing", "sources client", "users client", "pubsub"},
+		Run:       postsClient.Run,
+	})
+	registry.Register(lifecycle.Component{
+		Name:      "server",
+		DependsOn: []string{"users client", "posts client", "sources client"},
+		Run:       server.Run,
+	})
+	// surface wiring mistakes when building rather than when connecting
+	if _, err := registry.Order(); err != nil {
+		return nil, errors.Wrap(err, "failed to order components")
+	}
+
 	return &Strap{
 		Config:        cfg,
+		Logger:        logger,
 		UsersClient:   usersClient,
 		PostsClient:   postsClient,
 		SourcesClient: sourcesClient,
 		GraphServer:   server,
 
-		onconnect: map[string](func() (func() error, error)){
-			"users client run":   usersClient.Run,
-			"posts client run":   postsClient.Run,
-			"sources client run": sourcesClient.Run,
-			"server run":         server.Run,
-		},
-		onshutdown: map[string](func() error){},
+		registry: registry,
 	}, nil
 }
 
-// Connect connects all application services
-func (s *Strap) Connect() error {
-	for name, connect := range s.onconnect {
-		os, err := connect()
-		if err != nil {
-			return errors.Wrapf(err, "%s failed", name)
-		}
-		s.onshutdown[name] = os
+// Connect connects all application services in dependency order, shutting down
+// whatever already started if one of them fails
+func (s *Strap) Connect() (lifecycle.StartupReport, error) {
+	report, err := s.registry.Start(s.Config.Lifecycle.ShutdownTimeout)
+	if err != nil {
+		return report, errors.Wrap(err, "failed to connect")
 	}
-	return nil
+	return report, nil
 }
 
-// Shutdown shuts down all application srvices
-func (s *Strap) Shutdown() []error {
-	var errs []error
-	for name, shutdown := range s.onshutdown {
-		err := shutdown()
-		if err != nil {
-			errs = append(errs, errors.Wrapf(err, "%s failed", name))
-		}
-	}
-	return errs
+// Shutdown shuts down all application services in the reverse order they were started
+func (s *Strap) Shutdown(ctx context.Context) []error {
+	return s.registry.Stop(ctx)
 }
@@ -0,0 +1,544 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	servicesconfig "github.com/srcabl/services/pkg/config"
+	"gopkg.in/yaml.v2"
+)
+
+// Gateway is the gateway configuration. It extends the shared services gateway
+// config with the settings only the gateway cares about, read from the same file.
+type Gateway struct {
+	*servicesconfig.Gateway `yaml:"-"`
+
+	Lifecycle        Lifecycle        `yaml:"lifecycle"`
+	PasswordReset    PasswordReset    `yaml:"password_reset"`
+	Mailer           Mailer           `yaml:"mailer"`
+	Upstreams        Upstreams        `yaml:"upstreams"`
+	Logging          Logging          `yaml:"logging"`
+	Tracing          Tracing          `yaml:"tracing"`
+	Pagination       Pagination       `yaml:"pagination"`
+	Feed             Feed             `yaml:"feed"`
+	Provenance       Provenance       `yaml:"provenance"`
+	LinkPreview      LinkPreview      `yaml:"link_preview"`
+	Subscriptions    Subscriptions    `yaml:"subscriptions"`
+	Limits           Limits           `yaml:"limits"`
+	PersistedQueries PersistedQueries `yaml:"persisted_queries"`
+	RateLimits       RateLimits       `yaml:"rate_limits"`
+	Lockout          Lockout          `yaml:"lockout"`
+	CSRF             CSRF             `yaml:"csrf"`
+	Session          Session          `yaml:"session"`
+	Tokens           Tokens           `yaml:"tokens"`
+	OIDC             OIDC             `yaml:"oidc"`
+	Admin            Admin            `yaml:"admin"`
+}
+
+// Admin configures the listener for operator endpoints such as metrics, kept off the
+// public listener so they are only reachable from inside the network
+type Admin struct {
+	// Address is the address the admin listener binds to, defaults to loopback
+	Address string `yaml:"address"`
+	// Port is the port the admin listener binds to
+	Port int `yaml:"port"`
+}
+
+// OIDC configures signing in with OpenID Connect providers
+type OIDC struct {
+	// RedirectURL is where users are sent once they have signed in, with an error
+	// query parameter when they could not be
+	RedirectURL string `yaml:"redirect_url"`
+	// StateTTL is how long a user has to sign in with a provider
+	StateTTL time.Duration `yaml:"state_ttl"`
+	// Providers are keyed by their name in the /auth/{provider} endpoints
+	Providers map[string]OIDCProvider `yaml:"providers"`
+}
+
+// OIDCProvider configures an OpenID Connect provider
+type OIDCProvider struct {
+	// Issuer is the provider's issuer url, its metadata is discovered from it
+	Issuer       string `yaml:"issuer"`
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	// CallbackURL is the gateway's /auth/{provider}/callback url, as registered with the provider
+	CallbackURL string `yaml:"callback_url"`
+	// Scopes are requested on top of openid, defaults to email and profile
+	Scopes []string `yaml:"scopes"`
+	// LinkByEmail signs in as the user with the same email, when the provider has verified it
+	LinkByEmail bool `yaml:"link_by_email"`
+}
+
+// Tokens configures the bearer tokens issued to clients that cannot use the session cookie
+type Tokens struct {
+	// Secret keys the HMAC used to sign tokens, it is required and must not be any session key
+	Secret string `yaml:"secret"`
+	// AccessTTL is how long an access token is valid for
+	AccessTTL time.Duration `yaml:"access_ttl"`
+	// RefreshTTL is how long a refresh token is valid for
+	RefreshTTL time.Duration `yaml:"refresh_ttl"`
+	// Store is where outstanding refresh tokens are kept, one of memory or file. Tokens in the
+	// memory store are lost on restart and are only used up or revoked on the gateway that issued them.
+	Store string `yaml:"store"`
+	// Dir is the directory the file store keeps refresh tokens in, shared between gateways
+	Dir string `yaml:"dir"`
+}
+
+// Session configures the session cookie and where sessions are kept
+type Session struct {
+	// Store is where sessions are kept, one of cookie, memory or file. The memory and
+	// file stores keep only an opaque session id in the cookie, so sessions can be revoked.
+	Store string `yaml:"store"`
+	// Dir is the directory the file store keeps sessions in
+	Dir string `yaml:"dir"`
+	// Keys sign and encrypt the cookie. New cookies use the first, the rest are only
+	// accepted so that keys can be rotated. Defaults to the server session key.
+	Keys []SessionKey `yaml:"keys"`
+	// MaxAge is how long a session lasts
+	MaxAge time.Duration `yaml:"max_age"`
+	// Secure only sends the session and csrf cookies over https
+	Secure bool `yaml:"secure"`
+	// HTTPOnly stops scripts reading the session cookie, defaults to true
+	HTTPOnly *bool `yaml:"http_only"`
+	// SameSite is the SameSite attribute of the session cookie, one of lax, strict or none
+	SameSite string `yaml:"same_site"`
+	// Domain is the domain the session cookie is sent to, defaults to the host of the gateway
+	Domain string `yaml:"domain"`
+}
+
+// SessionKey signs and encrypts the session cookie
+type SessionKey struct {
+	// Auth signs the cookie
+	Auth string `yaml:"auth"`
+	// Encryption encrypts the cookie, it must be 16, 24 or 32 bytes. Defaults to one derived from Auth.
+	Encryption string `yaml:"encryption"`
+}
+
+// CSRF configures how mutations authenticated by the session cookie are protected from other sites
+type CSRF struct {
+	// Disabled stops mutations needing a csrf token, such as for the local playground
+	Disabled bool `yaml:"disabled"`
+}
+
+// Lockout configures how failed logins are delayed and locked out
+type Lockout struct {
+	// MaxFailures is how many failed logins lock an account out
+	MaxFailures int `yaml:"max_failures"`
+	// MaxIPFailures is how many failed logins, to any account, lock a client ip out
+	MaxIPFailures int `yaml:"max_ip_failures"`
+	// BaseDelay is how long to wait after the first failure, doubling with each one after
+	BaseDelay time.Duration `yaml:"base_delay"`
+	// MaxDelay caps how long to wait after a failure
+	MaxDelay time.Duration `yaml:"max_delay"`
+	// Duration is how long a lockout lasts
+	Duration time.Duration `yaml:"duration"`
+	// Window is how long failures are remembered after the last one
+	Window time.Duration `yaml:"window"`
+}
+
+// RateLimits configures how often each client ip and each user may call a mutation or query
+type RateLimits struct {
+	// TrustProxy takes the client ip from the X-Forwarded-For header set by a proxy in front of the gateway
+	TrustProxy bool `yaml:"trust_proxy"`
+	// Policies are keyed by mutation or query field name, fields without one are not limited
+	Policies map[string]RatePolicy `yaml:"policies"`
+}
+
+// RatePolicy allows Limit calls every Period, with up to Burst at once
+type RatePolicy struct {
+	Limit  int           `yaml:"limit"`
+	Period time.Duration `yaml:"period"`
+	// Burst defaults to Limit
+	Burst int `yaml:"burst"`
+}
+
+// PersistedQueries configures automatic persisted queries
+type PersistedQueries struct {
+	// Cache is where queries registered by clients are kept, only memory for now
+	Cache string `yaml:"cache"`
+	// CacheSize is the most queries the memory cache keeps
+	CacheSize int `yaml:"cache_size"`
+	// PersistedOnly rejects any query that is not in the manifest
+	PersistedOnly bool `yaml:"persisted_only"`
+	// Manifest is the json query manifest generated by the client build. Its operation names
+	// are the only ones metrics are labelled with, the rest are counted as other.
+	Manifest string `yaml:"manifest"`
+}
+
+// Limits bounds how deep and how complex a single graphql operation may be
+type Limits struct {
+	// MaxDepth is the deepest an operation may select
+	MaxDepth int `yaml:"max_depth"`
+	// MaxComplexity is the most an operation may cost, as counted from the @cost directives in the schema
+	MaxComplexity int `yaml:"max_complexity"`
+}
+
+// Subscriptions configures graphql subscriptions over websockets
+type Subscriptions struct {
+	// KeepAlive is how often an idle websocket is pinged to keep it open
+	KeepAlive time.Duration `yaml:"keep_alive"`
+	// BufferSize is how many events a subscriber may fall behind before it is disconnected
+	BufferSize int `yaml:"buffer_size"`
+}
+
+// LinkPreview configures how long previewed link sources are kept for posting
+type LinkPreview struct {
+	// CacheTTL is how long the sources determined for a previewed url are reused
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+	// CacheSize is the most urls kept at once
+	CacheSize int `yaml:"cache_size"`
+}
+
+// Provenance configures how the sources a story came from are walked
+type Provenance struct {
+	// MaxDepth is the most levels of sources walked, and the depth used when a query gives none
+	MaxDepth int `yaml:"max_depth"`
+}
+
+// Feed configures how the home feed is gathered
+type Feed struct {
+	// MaxConcurrentFetches bounds how many followed users and sources are read from the posts service at once
+	MaxConcurrentFetches int `yaml:"max_concurrent_fetches"`
+	// MaxFollowed bounds how many followed users and sources are read for each page, the
+	// followed users first. The feed leaves out any followed beyond it.
+	MaxFollowed int `yaml:"max_followed"`
+	// MaxSeenLinks bounds how many of the links already shown a feed cursor remembers, so
+	// they are not shown again on later pages
+	MaxSeenLinks int `yaml:"max_seen_links"`
+}
+
+// Pagination configures the page sizes of paginated queries
+type Pagination struct {
+	// DefaultPageSize is the page size when neither first nor last is given
+	DefaultPageSize int `yaml:"default_page_size"`
+	// MaxPageSize is the largest first or last a query may ask for
+	MaxPageSize int `yaml:"max_page_size"`
+}
+
+// Tracing configures OpenTelemetry tracing
+type Tracing struct {
+	// Exporter is where spans are sent, one of none, stdout, file or otlp
+	Exporter string `yaml:"exporter"`
+	// Path is the file spans are written to for the file exporter
+	Path string `yaml:"path"`
+	// Endpoint is the collector address for the otlp exporter
+	Endpoint string `yaml:"endpoint"`
+	// Insecure disables tls to the otlp collector
+	Insecure bool `yaml:"insecure"`
+	// SampleRatio is the fraction of new traces sampled, traces started upstream keep their decision
+	SampleRatio float64 `yaml:"sample_ratio"`
+	// ServiceName names the gateway in traces
+	ServiceName string `yaml:"service_name"`
+}
+
+// Logging configures the gateway logger
+type Logging struct {
+	// Level is the minimum level logged, one of debug, info, warn or error
+	Level string `yaml:"level"`
+	// Format is the log encoding, one of json or console
+	Format string `yaml:"format"`
+	// LevelEndpoint exposes /loglevel on the admin listener to read and change the level at runtime
+	LevelEndpoint bool `yaml:"level_endpoint"`
+}
+
+// Upstreams configures how the gateway reaches each grpc service
+type Upstreams struct {
+	Users   Upstream `yaml:"users"`
+	Posts   Upstream `yaml:"posts"`
+	Sources Upstream `yaml:"sources"`
+}
+
+// Upstream configures how the gateway reaches a single grpc service
+type Upstream struct {
+	// Target is a grpc target such as dns:///users.internal:8080, it defaults to
+	// localhost on the service port
+	Target string `yaml:"target"`
+	// Addresses is a static list of host:port addresses used instead of Target
+	Addresses []string `yaml:"addresses"`
+	// TLS configures transport security, the connection is insecure when disabled
+	TLS UpstreamTLS `yaml:"tls"`
+}
+
+// UpstreamTLS configures TLS and, when a cert and key are given, mTLS to an upstream
+type UpstreamTLS struct {
+	Enabled bool `yaml:"enabled"`
+	// CAFile verifies the server, the system roots are used when empty
+	CAFile string `yaml:"ca_file"`
+	// CertFile and KeyFile are the client certificate presented for mTLS
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// ServerName is the name the server certificate is verified against, it is required
+	// as the name grpc would otherwise use is not the server's for static addresses
+	ServerName string `yaml:"server_name"`
+}
+
+// Lifecycle configures how the gateway starts up and shuts down
+type Lifecycle struct {
+	// StartTimeout is how long each component is given to start
+	StartTimeout time.Duration `yaml:"start_timeout"`
+	// ShutdownTimeout is how long in flight requests are given to drain on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// ShutdownDelay is how long the server keeps serving after failing readiness, so load
+	// balancers stop routing to it before the listener closes. It counts towards ShutdownTimeout.
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+}
+
+// PasswordReset configures the password reset tokens
+type PasswordReset struct {
+	// Secret keys the HMAC used to sign reset tokens, it is required and must not be any session key
+	Secret string `yaml:"secret"`
+	// Store is where outstanding tokens are kept, one of memory or file. Tokens in the
+	// memory store are lost on restart and only work on the gateway that issued them.
+	Store string `yaml:"store"`
+	// Dir is the directory the file store keeps tokens in, shared between gateways
+	Dir string `yaml:"dir"`
+	// TTL is how long a reset token is valid for
+	TTL time.Duration `yaml:"ttl"`
+	// URL is the link sent to the user, %s is replaced with the token
+	URL string `yaml:"url"`
+}
+
+// Mailer configures how mail is sent
+type Mailer struct {
+	// Kind is the mailer implementation, one of log or file
+	Kind string `yaml:"kind"`
+	// Path is the file mail is written to for the file mailer
+	Path string `yaml:"path"`
+	// From is the address mail is sent from
+	From string `yaml:"from"`
+}
+
+// NewGateway reads the gateway config at the given path
+func NewGateway(path string) (*Gateway, error) {
+	shared, err := servicesconfig.NewGateway(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the shared gateway config")
+	}
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read config file %s", path)
+	}
+	cfg := &Gateway{Gateway: shared}
+	if err := yaml.Unmarshal(raw, cfg); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the gateway config")
+	}
+	cfg.setDefaults()
+	if err := cfg.validate(); err != nil {
+		return nil, errors.Wrap(err, "gateway config is not valid")
+	}
+	return cfg, nil
+}
+
+func (c *Gateway) setDefaults() {
+	if c.Lifecycle.StartTimeout == 0 {
+		c.Lifecycle.StartTimeout = 10 * time.Second
+	}
+	if c.Lifecycle.ShutdownTimeout == 0 {
+		c.Lifecycle.ShutdownTimeout = 15 * time.Second
+	}
+	if c.PasswordReset.Store == "" {
+		c.PasswordReset.Store = "memory"
+	}
+	if c.PasswordReset.Dir == "" {
+		c.PasswordReset.Dir = "reset_tokens"
+	}
+	if c.PasswordReset.TTL == 0 {
+		c.PasswordReset.TTL = time.Hour
+	}
+	if c.PasswordReset.URL == "" {
+		c.PasswordReset.URL = "http://localhost:3000/change-password?token=%s"
+	}
+	if c.Mailer.Kind == "" {
+		c.Mailer.Kind = "log"
+	}
+	if c.Mailer.From == "" {
+		c.Mailer.From = "no-reply@srcabl.com"
+	}
+	if c.Logging.Level == "" {
+		c.Logging.Level = "info"
+	}
+	if c.Logging.Format == "" {
+		c.Logging.Format = "json"
+	}
+	if c.Tracing.Exporter == "" {
+		c.Tracing.Exporter = "none"
+	}
+	if c.Tracing.SampleRatio == 0 {
+		c.Tracing.SampleRatio = 1
+	}
+	if c.Tracing.ServiceName == "" {
+		c.Tracing.ServiceName = "gateway"
+	}
+	if c.Pagination.MaxPageSize == 0 {
+		c.Pagination.MaxPageSize = 50
+	}
+	if c.Pagination.DefaultPageSize == 0 {
+		c.Pagination.DefaultPageSize = 20
+	}
+	if c.Pagination.DefaultPageSize > c.Pagination.MaxPageSize {
+		c.Pagination.DefaultPageSize = c.Pagination.MaxPageSize
+	}
+	if c.Feed.MaxConcurrentFetches == 0 {
+		c.Feed.MaxConcurrentFetches = 8
+	}
+	if c.Feed.MaxFollowed == 0 {
+		c.Feed.MaxFollowed = 200
+	}
+	if c.Feed.MaxSeenLinks == 0 {
+		c.Feed.MaxSeenLinks = 100
+	}
+	if c.Provenance.MaxDepth == 0 {
+		c.Provenance.MaxDepth = 5
+	}
+	if c.LinkPreview.CacheTTL == 0 {
+		c.LinkPreview.CacheTTL = 5 * time.Minute
+	}
+	if c.LinkPreview.CacheSize == 0 {
+		c.LinkPreview.CacheSize = 1000
+	}
+	if c.Subscriptions.KeepAlive == 0 {
+		c.Subscriptions.KeepAlive = 10 * time.Second
+	}
+	if c.Subscriptions.BufferSize == 0 {
+		c.Subscriptions.BufferSize = 16
+	}
+	if c.Limits.MaxDepth == 0 {
+		c.Limits.MaxDepth = 10
+	}
+	if c.Limits.MaxComplexity == 0 {
+		c.Limits.MaxComplexity = 2000
+	}
+	if c.PersistedQueries.Cache == "" {
+		c.PersistedQueries.Cache = "memory"
+	}
+	if c.PersistedQueries.CacheSize == 0 {
+		c.PersistedQueries.CacheSize = 1000
+	}
+	if c.RateLimits.Policies == nil {
+		c.RateLimits.Policies = map[string]RatePolicy{
+			"login":          {Limit: 5, Period: time.Minute},
+			"register":       {Limit: 5, Period: time.Hour},
+			"forgotPassword": {Limit: 3, Period: time.Hour},
+			"createPost":     {Limit: 30, Period: time.Minute},
+			"previewLink":    {Limit: 30, Period: time.Minute},
+		}
+	}
+	if c.Lockout.MaxFailures == 0 {
+		c.Lockout.MaxFailures = 5
+	}
+	if c.Lockout.MaxIPFailures == 0 {
+		c.Lockout.MaxIPFailures = 20
+	}
+	if c.Lockout.BaseDelay == 0 {
+		c.Lockout.BaseDelay = time.Second
+	}
+	if c.Lockout.MaxDelay == 0 {
+		c.Lockout.MaxDelay = 30 * time.Second
+	}
+	if c.Lockout.Duration == 0 {
+		c.Lockout.Duration = 15 * time.Minute
+	}
+	if c.Lockout.Window == 0 {
+		c.Lockout.Window = 15 * time.Minute
+	}
+	if c.Session.Store == "" {
+		c.Session.Store = "cookie"
+	}
+	if c.Session.Dir == "" {
+		c.Session.Dir = "sessions"
+	}
+	if len(c.Session.Keys) == 0 {
+		c.Session.Keys = []SessionKey{{Auth: c.Server.SessionKey}}
+	}
+	if c.Session.MaxAge == 0 {
+		c.Session.MaxAge = 30 * 24 * time.Hour
+	}
+	if c.Session.HTTPOnly == nil {
+		httpOnly := true
+		c.Session.HTTPOnly = &httpOnly
+	}
+	if c.Session.SameSite == "" {
+		c.Session.SameSite = "lax"
+	}
+	if c.Tokens.AccessTTL == 0 {
+		c.Tokens.AccessTTL = 15 * time.Minute
+	}
+	if c.Tokens.RefreshTTL == 0 {
+		c.Tokens.RefreshTTL = 30 * 24 * time.Hour
+	}
+	if c.Tokens.Store == "" {
+		c.Tokens.Store = "memory"
+	}
+	if c.Tokens.Dir == "" {
+		c.Tokens.Dir = "refresh_tokens"
+	}
+	if c.OIDC.RedirectURL == "" {
+		c.OIDC.RedirectURL = "http://localhost:3000/"
+	}
+	if c.OIDC.StateTTL == 0 {
+		c.OIDC.StateTTL = 10 * time.Minute
+	}
+	for name, p := range c.OIDC.Providers {
+		if p.Scopes == nil {
+			p.Scopes = []string{"email", "profile"}
+			c.OIDC.Providers[name] = p
+		}
+	}
+	if c.Admin.Address == "" {
+		c.Admin.Address = "127.0.0.1"
+	}
+	if c.Admin.Port == 0 {
+		c.Admin.Port = 9090
+	}
+	c.Upstreams.Users.setDefaults(c.Services.UsersPort)
+	c.Upstreams.Posts.setDefaults(c.Services.PostsPort)
+	c.Upstreams.Sources.setDefaults(c.Services.SourcesPort)
+}
+
+func (c *Gateway) validate() error {
+	if c.PasswordReset.Secret == "" {
+		return errors.New("password_reset.secret is required")
+	}
+	if c.isSessionKey(c.PasswordReset.Secret) {
+		return errors.New("password_reset.secret must not be a session key")
+	}
+	if c.Tokens.Secret == "" {
+		return errors.New("tokens.secret is required")
+	}
+	if c.isSessionKey(c.Tokens.Secret) {
+		return errors.New("tokens.secret must not be a session key")
+	}
+	// revoking a user's tokens clears every file of theirs in the directory, whichever kind it is
+	if c.Tokens.Store == "file" && c.PasswordReset.Store == "file" && filepath.Clean(c.Tokens.Dir) == filepath.Clean(c.PasswordReset.Dir) {
+		return errors.New("tokens.dir must not be the password_reset.dir")
+	}
+	for name, u := range map[string]Upstream{"users": c.Upstreams.Users, "posts": c.Upstreams.Posts, "sources": c.Upstreams.Sources} {
+		if u.TLS.Enabled && u.TLS.ServerName == "" {
+			return errors.Errorf("upstreams.%s.tls.server_name is required when tls is enabled", name)
+		}
+	}
+	return nil
+}
+
+// isSessionKey reports whether the secret is the server session key or any key,
+// auth or encryption, the session cookie is signed or encrypted with
+func (c *Gateway) isSessionKey(secret string) bool {
+	if secret == c.Server.SessionKey {
+		return true
+	}
+	for _, key := range c.Session.Keys {
+		if secret == key.Auth || secret == key.Encryption {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *Upstream) setDefaults(port int) {
+	if u.Target == "" && len(u.Addresses) == 0 {
+		u.Target = fmt.Sprintf("localhost:%d", port)
+	}
+}
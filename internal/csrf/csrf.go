@@ -0,0 +1,115 @@
+package csrf
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/middleware"
+	"github.com/srcabl/gateway/internal/token"
+)
+
+const (
+	// CookieName is the cookie the token is issued in
+	CookieName = "csrf_token"
+	// HeaderName is the header the token must be sent back in
+	HeaderName = "X-CSRF-Token"
+	// tokenLength is how many random bytes make a token
+	tokenLength = 32
+)
+
+var (
+	// ErrMissingToken is returned when a mutation does not carry the token in both the cookie and the header
+	ErrMissingToken = errors.New("csrf token is missing")
+	// ErrInvalidToken is returned when the token in the header does not match the one in the cookie
+	ErrInvalidToken = errors.New("csrf token is not valid")
+	// ErrNotJSON is returned when a mutation is sent in a form or other content type a cross site page can send
+	ErrNotJSON = errors.New("mutations must be sent as application/json")
+	// ErrNotPost is returned when a mutation is sent with a method other than POST
+	ErrNotPost = errors.New("mutations must be sent with POST")
+)
+
+// Protector protects mutations authenticated by the session cookie from being sent by
+// other sites. Mutations must be JSON POSTs, which a page on another site cannot send
+// without CORS allowing it, and carry a double submit token: the token issued in a
+// cookie sent back in a header, which a page on another site cannot read.
+type Protector struct {
+	disabled bool
+	secure   bool
+}
+
+// New news up a protector, only sending the token cookie over https when secure. When
+// disabled, such as for the local playground, mutations do not need a token but must
+// still be JSON POSTs.
+func New(cfg config.CSRF, secure bool) *Protector {
+	return &Protector{
+		disabled: cfg.Disabled,
+		secure:   secure,
+	}
+}
+
+// Token handles issuing the csrf token, reusing the one the client already holds
+func (p *Protector) Token(ctx context.Context) (string, error) {
+	httpContext, ok := middleware.GetHTTP(ctx)
+	if !ok {
+		return "", errors.New("csrf tokens can only be issued over http")
+	}
+	if cookie, err := httpContext.R.Cookie(CookieName); err == nil && validToken(cookie.Value) {
+		return cookie.Value, nil
+	}
+	b := make([]byte, tokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate csrf token")
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	http.SetCookie(*httpContext.W, &http.Cookie{
+		Name:     CookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   p.secure,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return token, nil
+}
+
+// Check checks that a mutation request could not have been sent by a page on another site
+func (p *Protector) Check(r *http.Request) error {
+	// websockets are upgraded only from allowed origins, which covers everything sent over them
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return nil
+	}
+	if r.Method != http.MethodPost {
+		return ErrNotPost
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return ErrNotJSON
+	}
+	// without cookies, or authenticated by a bearer token that a page on another site
+	// could not have attached, the request cannot be riding on a session
+	if p.disabled || len(r.Cookies()) == 0 || token.FromHeader(r.Header.Get("Authorization")) != "" {
+		return nil
+	}
+	header := r.Header.Get(HeaderName)
+	cookie, err := r.Cookie(CookieName)
+	if err != nil || header == "" {
+		return ErrMissingToken
+	}
+	if !validToken(cookie.Value) || subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) != 1 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// validToken reports whether the token is one that could have been issued
+func validToken(token string) bool {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	return err == nil && len(b) == tokenLength
+}
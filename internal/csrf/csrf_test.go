@@ -0,0 +1,121 @@
+package csrf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/middleware"
+)
+
+const testToken = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+
+func TestCheck(t *testing.T) {
+	other := strings.Repeat("B", len(testToken))
+	tests := []struct {
+		name        string
+		disabled    bool
+		method      string
+		contentType string
+		upgrade     string
+		cookies     map[string]string
+		header      string
+		bearer      bool
+		wantErr     error
+	}{
+		{name: "websocket", method: "GET", upgrade: "websocket"},
+		{name: "get", method: "GET", contentType: "application/json", wantErr: ErrNotPost},
+		{name: "form", method: "POST", contentType: "application/x-www-form-urlencoded", wantErr: ErrNotJSON},
+		{name: "text plain", method: "POST", contentType: "text/plain", wantErr: ErrNotJSON},
+		{name: "no content type", method: "POST", wantErr: ErrNotJSON},
+		{name: "json with charset and no cookies", method: "POST", contentType: "application/json; charset=utf-8"},
+		{name: "session without token", method: "POST", contentType: "application/json",
+			cookies: map[string]string{"session": "s"}, wantErr: ErrMissingToken},
+		{name: "cookie without header", method: "POST", contentType: "application/json",
+			cookies: map[string]string{CookieName: testToken}, wantErr: ErrMissingToken},
+		{name: "header without cookie", method: "POST", contentType: "application/json",
+			cookies: map[string]string{"session": "s"}, header: testToken, wantErr: ErrMissingToken},
+		{name: "mismatch", method: "POST", contentType: "application/json",
+			cookies: map[string]string{CookieName: testToken}, header: other, wantErr: ErrInvalidToken},
+		{name: "malformed cookie", method: "POST", contentType: "application/json",
+			cookies: map[string]string{CookieName: "short"}, header: "short", wantErr: ErrInvalidToken},
+		{name: "match", method: "POST", contentType: "application/json",
+			cookies: map[string]string{CookieName: testToken}, header: testToken},
+		{name: "bearer", method: "POST", contentType: "application/json",
+			cookies: map[string]string{"session": "s"}, bearer: true},
+		{name: "disabled", disabled: true, method: "POST", contentType: "application/json",
+			cookies: map[string]string{"session": "s"}},
+		{name: "disabled still needs json", disabled: true, method: "POST", contentType: "text/plain", wantErr: ErrNotJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/graphql", nil)
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.upgrade != "" {
+				r.Header.Set("Upgrade", tt.upgrade)
+			}
+			for name, value := range tt.cookies {
+				r.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+			if tt.header != "" {
+				r.Header.Set(HeaderName, tt.header)
+			}
+			if tt.bearer {
+				r.Header.Set("Authorization", "Bearer abc")
+			}
+			p := New(config.CSRF{Disabled: tt.disabled}, true)
+			if err := p.Check(r); err != tt.wantErr {
+				t.Errorf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		cookie    string
+		wantReuse bool
+	}{
+		{name: "new client"},
+		{name: "existing token", cookie: testToken, wantReuse: true},
+		{name: "malformed token", cookie: "short"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/graphql", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: CookieName, Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			var w http.ResponseWriter = rec
+			ctx := context.WithValue(context.Background(), middleware.HTTPKey, middleware.HTTP{W: &w, R: r})
+
+			token, err := New(config.CSRF{}, true).Token(ctx)
+			if err != nil {
+				t.Fatalf("Token() error = %v", err)
+			}
+			if !validToken(token) {
+				t.Errorf("Token() = %q, want a valid token", token)
+			}
+			set := rec.Result().Cookies()
+			if tt.wantReuse {
+				if token != tt.cookie || len(set) != 0 {
+					t.Errorf("Token() = %q setting %d cookies, want the existing token reused", token, len(set))
+				}
+				return
+			}
+			if len(set) != 1 || set[0].Value != token || !set[0].HttpOnly || !set[0].Secure || set[0].SameSite != http.SameSiteStrictMode {
+				t.Errorf("Token() set cookies %+v, want one strict, secure, http only cookie holding the token", set)
+			}
+		})
+	}
+	if _, err := New(config.CSRF{}, true).Token(context.Background()); err == nil {
+		t.Error("Token() outside of http succeeded, want an error")
+	}
+}
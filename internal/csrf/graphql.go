@@ -0,0 +1,51 @@
+package csrf
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/srcabl/gateway/internal/middleware"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// GraphQL returns the gqlgen extension checking every mutation before it is executed
+func (p *Protector) GraphQL() graphql.HandlerExtension {
+	return &graphqlExtension{protector: p}
+}
+
+type graphqlExtension struct {
+	protector *Protector
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = &graphqlExtension{}
+
+// ExtensionName names the extension
+func (e *graphqlExtension) ExtensionName() string {
+	return "CSRF"
+}
+
+// Validate has nothing to validate
+func (e *graphqlExtension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// MutateOperationContext rejects mutations that could have been sent by a page on another site
+func (e *graphqlExtension) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	if rc.Operation == nil || rc.Operation.Operation != ast.Mutation {
+		return nil
+	}
+	httpContext, ok := middleware.GetHTTP(ctx)
+	if !ok {
+		return nil
+	}
+	if err := e.protector.Check(httpContext.R); err != nil {
+		gqlErr := gqlerror.Errorf("%s", err.Error())
+		gqlErr.Extensions = map[string]interface{}{"code": "CSRF_CHECK_FAILED"}
+		return gqlErr
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package dataloader
+
+//go:generate go run github.com/vektah/dataloaden UserLoader string *github.com/srcabl/gateway/graph/model.PartialUser
+//go:generate go run github.com/vektah/dataloaden LinkLoader string *github.com/srcabl/gateway/graph/model.PartialLink
+//go:generate go run github.com/vektah/dataloaden SourceLoader string *github.com/srcabl/gateway/graph/model.PartialSource
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/graph/model"
+)
+
+const (
+	// wait is how long keys are collected before a batch is fetched
+	wait = 2 * time.Millisecond
+	// maxBatch bounds how many keys are sent to an upstream in one call
+	maxBatch = 100
+)
+
+// loadersKey is the key the loaders are stored under in the context
+type loadersKey struct{}
+
+// ErrNoLoaders is returned when the loaders are used outside of a request
+var ErrNoLoaders = errors.New("dataloaders are not in the context")
+
+// Fetchers are the bulk upstream calls behind the loaders. Each returns the
+// values in the same order as the keys, with nil for keys that do not exist.
+type Fetchers struct {
+	Users   func(ctx context.Context, ids []string) ([]*model.PartialUser, []error)
+	Links   func(ctx context.Context, ids []string) ([]*model.PartialLink, []error)
+	Sources func(ctx context.Context, ids []string) ([]*model.PartialSource, []error)
+}
+
+// Loaders batch, dedupe and cache the upstream lookups made while resolving a single request
+type Loaders struct {
+	UsersByID   *UserLoader
+	LinksByID   *LinkLoader
+	SourcesByID *SourceLoader
+}
+
+// New news up the loaders for a single request. The fetches run with the
+// request context so they are cancelled, traced and tagged along with it.
+func New(ctx context.Context, f Fetchers) *Loaders {
+	return &Loaders{
+		UsersByID: NewUserLoader(UserLoaderConfig{
+			Fetch: func(keys []string) ([]*model.PartialUser, []error) {
+				return f.Users(ctx, keys)
+			},
+			Wait:     wait,
+			MaxBatch: maxBatch,
+		}),
+		LinksByID: NewLinkLoader(LinkLoaderConfig{
+			Fetch: func(keys []string) ([]*model.PartialLink, []error) {
+				return f.Links(ctx, keys)
+			},
+			Wait:     wait,
+			MaxBatch: maxBatch,
+		}),
+		SourcesByID: NewSourceLoader(SourceLoaderConfig{
+			Fetch: func(keys []string) ([]*model.PartialSource, []error) {
+				return f.Sources(ctx, keys)
+			},
+			Wait:     wait,
+			MaxBatch: maxBatch,
+		}),
+	}
+}
+
+// Middleware attaches a fresh set of loaders to every request so nothing is cached across requests
+func Middleware(f Fetchers) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := WithLoaders(r.Context(), New(r.Context(), f))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// WithLoaders returns a context carrying the loaders
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+// For returns the loaders for the request in the context
+func For(ctx context.Context) (*Loaders, error) {
+	loaders, ok := ctx.Value(loadersKey{}).(*Loaders)
+	if !ok {
+		return nil, ErrNoLoaders
+	}
+	return loaders, nil
+}
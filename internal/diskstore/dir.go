@@ -0,0 +1,221 @@
+package diskstore
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// sweepInterval is how often expired files are cleared out
+	sweepInterval = time.Hour
+	// headerLength is the length of when a record expires and the length of its owner
+	headerLength = 9
+	// maxOwnerLength is the longest owner whose length fits in the header
+	maxOwnerLength = 255
+)
+
+// Record is what is kept for each id
+type Record struct {
+	// Owner is who the record belongs to, so all of theirs can be removed at once
+	Owner   string
+	Data    []byte
+	Expires time.Time
+}
+
+// Dir keeps each record in its own file in a directory, named by its id. Records are
+// written to a temporary file and renamed into place so they are never read half
+// written, and removing one removes its file, so on a directory shared between
+// gateways a record is only ever removed once.
+type Dir struct {
+	dir       string
+	kind      string
+	validID   func(id string) bool
+	mu        sync.Mutex
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// New news up a directory of the kind of record, such as session, refusing ids that
+// validID does not accept so an id can never name a file outside the directory
+func New(dir, kind string, validID func(id string) bool) (*Dir, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "failed to create %s directory %s", kind, dir)
+	}
+	return &Dir{
+		dir:     dir,
+		kind:    kind,
+		validID: validID,
+		now:     time.Now,
+	}, nil
+}
+
+// Read reads the record, reporting false if it does not exist or has expired
+func (d *Dir) Read(id string) (Record, bool, error) {
+	path, err := d.path(id)
+	if err != nil {
+		return Record{}, false, err
+	}
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Record{}, false, nil
+	}
+	if err != nil {
+		return Record{}, false, errors.Wrapf(err, "failed to read %s file", d.kind)
+	}
+	r, ok := decode(raw)
+	if !ok || !d.now().Before(r.Expires) {
+		return Record{}, false, nil
+	}
+	return r, true, nil
+}
+
+// Write writes the record, clearing out any that have expired
+func (d *Dir) Write(id string, r Record) error {
+	path, err := d.path(id)
+	if err != nil {
+		return err
+	}
+	raw, err := encode(r)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode %s file", d.kind)
+	}
+	d.sweep()
+	tmp, err := ioutil.TempFile(d.dir, "tmp-")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s file", d.kind)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "failed to write %s file", d.kind)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write %s file", d.kind)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrapf(err, "failed to move %s file into place", d.kind)
+	}
+	return nil
+}
+
+// Remove removes the record, reporting whether it existed
+func (d *Dir) Remove(id string) (bool, error) {
+	path, err := d.path(id)
+	if err != nil {
+		return false, err
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to remove %s file", d.kind)
+	}
+	return true, nil
+}
+
+// RemoveOwner removes every record of the owner, reading through every file
+func (d *Dir) RemoveOwner(owner string) error {
+	return d.each(func(path string, r Record, ok bool) error {
+		if !ok || r.Owner != owner {
+			return nil
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove %s file", d.kind)
+		}
+		return nil
+	})
+}
+
+// sweep clears out expired files, at most once every sweep interval
+func (d *Dir) sweep() {
+	d.mu.Lock()
+	now := d.now()
+	if now.Sub(d.lastSweep) < sweepInterval {
+		d.mu.Unlock()
+		return
+	}
+	d.lastSweep = now
+	d.mu.Unlock()
+	// a failed sweep is tried again next interval
+	d.each(func(path string, r Record, ok bool) error {
+		if !ok || !now.Before(r.Expires) {
+			os.Remove(path)
+		}
+		return nil
+	})
+}
+
+// each calls fn with every record file in the directory, ok is false for files that
+// do not decode. Files removed while reading through are skipped.
+func (d *Dir) each(fn func(path string, r Record, ok bool) error) error {
+	files, err := ioutil.ReadDir(d.dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s directory", d.kind)
+	}
+	suffix := d.suffix()
+	for _, info := range files {
+		if !strings.HasSuffix(info.Name(), suffix) {
+			continue
+		}
+		path := filepath.Join(d.dir, info.Name())
+		raw, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s file", d.kind)
+		}
+		r, ok := decode(raw)
+		if err := fn(path, r, ok); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// path gets the file of the record, refusing ids that could not have been generated
+func (d *Dir) path(id string) (string, error) {
+	if !d.validID(id) {
+		return "", errors.Errorf("%s id is not valid", d.kind)
+	}
+	return filepath.Join(d.dir, id+d.suffix()), nil
+}
+
+// suffix ends the name of every record file
+func (d *Dir) suffix() string {
+	return "." + d.kind
+}
+
+// encode lays a record out as when it expires, the length of its owner, the owner
+// and then the data
+func encode(r Record) ([]byte, error) {
+	if len(r.Owner) > maxOwnerLength {
+		return nil, errors.New("owner is too long")
+	}
+	raw := make([]byte, headerLength, headerLength+len(r.Owner)+len(r.Data))
+	binary.BigEndian.PutUint64(raw, uint64(r.Expires.UnixNano()))
+	raw[8] = byte(len(r.Owner))
+	raw = append(raw, r.Owner...)
+	return append(raw, r.Data...), nil
+}
+
+// decode splits a file into its record
+func decode(raw []byte) (Record, bool) {
+	if len(raw) < headerLength || len(raw) < headerLength+int(raw[8]) {
+		return Record{}, false
+	}
+	n := headerLength + int(raw[8])
+	return Record{
+		Owner:   string(raw[headerLength:n]),
+		Data:    raw[n:],
+		Expires: time.Unix(0, int64(binary.BigEndian.Uint64(raw[:8]))),
+	}, true
+}
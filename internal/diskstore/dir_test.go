@@ -0,0 +1,141 @@
+package diskstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validID(id string) bool {
+	return len(id) == 4 && !strings.ContainsAny(id, `./\`)
+}
+
+func newDir(t *testing.T) (*Dir, *time.Time) {
+	t.Helper()
+	d, err := New(t.TempDir(), "test", validID)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	now := time.Now()
+	d.now = func() time.Time { return now }
+	return d, &now
+}
+
+func write(t *testing.T, d *Dir, id string, r Record) {
+	t.Helper()
+	if err := d.Write(id, r); err != nil {
+		t.Fatalf("Write(%s) error = %v", id, err)
+	}
+}
+
+func exists(t *testing.T, d *Dir, id string) bool {
+	t.Helper()
+	_, ok, err := d.Read(id)
+	if err != nil {
+		t.Fatalf("Read(%s) error = %v", id, err)
+	}
+	return ok
+}
+
+func TestReadWrite(t *testing.T) {
+	d, now := newDir(t)
+	want := Record{Owner: "alice", Data: []byte("data"), Expires: now.Add(time.Minute)}
+	write(t, d, "aaaa", want)
+
+	got, ok, err := d.Read("aaaa")
+	if err != nil || !ok {
+		t.Fatalf("Read() = %v, %v", ok, err)
+	}
+	if got.Owner != want.Owner || string(got.Data) != string(want.Data) || !got.Expires.Equal(want.Expires) {
+		t.Errorf("Read() = %+v, want %+v", got, want)
+	}
+	if exists(t, d, "bbbb") {
+		t.Error("Read() of a missing record reported it exists")
+	}
+
+	write(t, d, "aaaa", Record{Owner: "bob", Expires: now.Add(time.Minute)})
+	if got, _, _ := d.Read("aaaa"); got.Owner != "bob" || len(got.Data) != 0 {
+		t.Errorf("Read() after overwriting = %+v, want bob's record", got)
+	}
+
+	*now = now.Add(time.Minute)
+	if exists(t, d, "aaaa") {
+		t.Error("Read() of an expired record reported it exists")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	d, now := newDir(t)
+	write(t, d, "aaaa", Record{Expires: now.Add(time.Minute)})
+	if removed, err := d.Remove("aaaa"); err != nil || !removed {
+		t.Fatalf("Remove() = %v, %v, want true", removed, err)
+	}
+	if removed, err := d.Remove("aaaa"); err != nil || removed {
+		t.Errorf("second Remove() = %v, %v, want false", removed, err)
+	}
+}
+
+func TestRemoveOwner(t *testing.T) {
+	d, now := newDir(t)
+	expires := now.Add(time.Minute)
+	write(t, d, "aaa1", Record{Owner: "alice", Expires: expires})
+	write(t, d, "aaa2", Record{Owner: "alice", Expires: expires})
+	write(t, d, "bbb1", Record{Owner: "bob", Expires: expires})
+	write(t, d, "nobo", Record{Expires: expires})
+
+	if err := d.RemoveOwner("alice"); err != nil {
+		t.Fatalf("RemoveOwner() error = %v", err)
+	}
+	for id, want := range map[string]bool{"aaa1": false, "aaa2": false, "bbb1": true, "nobo": true} {
+		if got := exists(t, d, id); got != want {
+			t.Errorf("%s exists = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestSweep(t *testing.T) {
+	d, now := newDir(t)
+	write(t, d, "aaaa", Record{Expires: now.Add(time.Minute)})
+	write(t, d, "bbbb", Record{Expires: now.Add(2 * sweepInterval)})
+	other := filepath.Join(d.dir, "other.file")
+	if err := ioutil.WriteFile(other, nil, 0600); err != nil {
+		t.Fatalf("failed to write another file: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(d.dir, "junk.test"), []byte("x"), 0600); err != nil {
+		t.Fatalf("failed to write a junk file: %v", err)
+	}
+
+	*now = now.Add(sweepInterval)
+	write(t, d, "cccc", Record{Expires: now.Add(time.Minute)})
+	for name, want := range map[string]bool{"aaaa.test": false, "junk.test": false, "bbbb.test": true, "cccc.test": true, "other.file": true} {
+		_, err := os.Stat(filepath.Join(d.dir, name))
+		if got := err == nil; got != want {
+			t.Errorf("%s kept = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestRefusesBadIDs(t *testing.T) {
+	d, now := newDir(t)
+	for _, id := range []string{"", "../x", "a/bc", "toolong"} {
+		if err := d.Write(id, Record{Expires: now.Add(time.Minute)}); err == nil {
+			t.Errorf("Write(%q) succeeded, want an error", id)
+		}
+		if _, _, err := d.Read(id); err == nil {
+			t.Errorf("Read(%q) succeeded, want an error", id)
+		}
+		if _, err := d.Remove(id); err == nil {
+			t.Errorf("Remove(%q) succeeded, want an error", id)
+		}
+	}
+}
+
+func TestRefusesLongOwners(t *testing.T) {
+	d, now := newDir(t)
+	if err := d.Write("aaaa", Record{Owner: strings.Repeat("a", maxOwnerLength+1), Expires: now.Add(time.Minute)}); err == nil {
+		t.Error("Write() with a long owner succeeded, want an error")
+	}
+}
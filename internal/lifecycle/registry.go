@@ -0,0 +1,189 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Component is a named piece of the application that is started on connect
+type Component struct {
+	Name string
+	// DependsOn lists the names of the components that must be started first
+	DependsOn []string
+	// StartTimeout overrides the registry start timeout when set
+	StartTimeout time.Duration
+	Run          func() (func(context.Context) error, error)
+}
+
+// StartupEntry records how a single component started
+type StartupEntry struct {
+	Name     string
+	Duration time.Duration
+}
+
+// StartupReport lists the components that started and how long each took
+type StartupReport []StartupEntry
+
+// String formats the report for printing
+func (r StartupReport) String() string {
+	var b strings.Builder
+	b.WriteString("Startup report:")
+	var total time.Duration
+	for _, e := range r {
+		total += e.Duration
+		fmt.Fprintf(&b, "\n  %-24s %s", e.Name, e.Duration)
+	}
+	fmt.Fprintf(&b, "\n  %-24s %s", "total", total)
+	return b.String()
+}
+
+// Registry starts components in dependency order and stops them in reverse
+type Registry struct {
+	startTimeout time.Duration
+	components   []Component
+	started      []startedComponent
+	report       StartupReport
+}
+
+// startedComponent is a component that is running and must be shut down
+type startedComponent struct {
+	name     string
+	shutdown func(context.Context) error
+}
+
+// NewRegistry news up a component registry
+func NewRegistry(startTimeout time.Duration) *Registry {
+	return &Registry{
+		startTimeout: startTimeout,
+	}
+}
+
+// Register adds a component to the registry
+func (r *Registry) Register(c Component) {
+	r.components = append(r.components, c)
+}
+
+// Order resolves the start order of the registered components, failing on
+// duplicate names, missing dependencies and cycles
+func (r *Registry) Order() ([]Component, error) {
+	byName := make(map[string]Component, len(r.components))
+	for _, c := range r.components {
+		if _, ok := byName[c.Name]; ok {
+			return nil, errors.Errorf("component %q is registered more than once", c.Name)
+		}
+		byName[c.Name] = c
+	}
+	for _, c := range r.components {
+		for _, dep := range c.DependsOn {
+			if _, ok := byName[dep]; !ok {
+				return nil, errors.Errorf("component %q depends on missing component %q", c.Name, dep)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(r.components))
+	ordered := make([]Component, 0, len(r.components))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return errors.Errorf("dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+		state[name] = visiting
+		c := byName[name]
+		for _, dep := range c.DependsOn {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		ordered = append(ordered, c)
+		return nil
+	}
+	// visit in registration order so the result is deterministic
+	for _, c := range r.components {
+		if err := visit(c.Name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// Start starts every component in dependency order, shutting down whatever
+// already started if one of them fails
+func (r *Registry) Start(shutdownTimeout time.Duration) (StartupReport, error) {
+	ordered, err := r.Order()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to order components")
+	}
+	for _, c := range ordered {
+		began := time.Now()
+		shutdown, err := r.start(c)
+		if err != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			r.Stop(ctx)
+			return r.report, errors.Wrapf(err, "%s failed", c.Name)
+		}
+		r.started = append(r.started, startedComponent{name: c.Name, shutdown: shutdown})
+		r.report = append(r.report, StartupEntry{Name: c.Name, Duration: time.Since(began)})
+	}
+	return r.report, nil
+}
+
+// start runs a single component, giving up once its start timeout passes
+func (r *Registry) start(c Component) (func(context.Context) error, error) {
+	timeout := c.StartTimeout
+	if timeout == 0 {
+		timeout = r.startTimeout
+	}
+	type result struct {
+		shutdown func(context.Context) error
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		shutdown, err := c.Run()
+		done <- result{shutdown: shutdown, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		return res.shutdown, res.err
+	case <-timer.C:
+		// if the component does eventually start, stop it straight away
+		go func() {
+			res := <-done
+			if res.err == nil && res.shutdown != nil {
+				res.shutdown(context.Background())
+			}
+		}()
+		return nil, errors.Errorf("timed out after %s", timeout)
+	}
+}
+
+// Stop shuts down all started components in the reverse order they were started
+func (r *Registry) Stop(ctx context.Context) []error {
+	var errs []error
+	for i := len(r.started) - 1; i >= 0; i-- {
+		c := r.started[i]
+		if err := c.shutdown(ctx); err != nil {
+			errs = append(errs, errors.Wrapf(err, "%s shutdown failed", c.name))
+		}
+	}
+	r.started = nil
+	return errs
+}
@@ -0,0 +1,222 @@
+package lifecycle
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func names(components []Component) string {
+	var ns []string
+	for _, c := range components {
+		ns = append(ns, c.Name)
+	}
+	return strings.Join(ns, ",")
+}
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []Component
+		order      string
+		err        string
+	}{
+		{
+			name:  "none",
+			order: "",
+		},
+		{
+			name: "registration order without dependencies",
+			components: []Component{
+				{Name: "a"}, {Name: "b"}, {Name: "c"},
+			},
+			order: "a,b,c",
+		},
+		{
+			name: "dependencies first",
+			components: []Component{
+				{Name: "server", DependsOn: []string{"posts", "users"}},
+				{Name: "posts", DependsOn: []string{"users", "tracing"}},
+				{Name: "users", DependsOn: []string{"tracing"}},
+				{Name: "tracing"},
+			},
+			order: "tracing,users,posts,server",
+		},
+		{
+			name: "diamond",
+			components: []Component{
+				{Name: "top", DependsOn: []string{"left", "right"}},
+				{Name: "left", DependsOn: []string{"bottom"}},
+				{Name: "right", DependsOn: []string{"bottom"}},
+				{Name: "bottom"},
+			},
+			order: "bottom,left,right,top",
+		},
+		{
+			name: "duplicate",
+			components: []Component{
+				{Name: "a"}, {Name: "a"},
+			},
+			err: `component "a" is registered more than once`,
+		},
+		{
+			name: "missing dependency",
+			components: []Component{
+				{Name: "a", DependsOn: []string{"b"}},
+			},
+			err: `component "a" depends on missing component "b"`,
+		},
+		{
+			name: "cycle",
+			components: []Component{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"c"}},
+				{Name: "c", DependsOn: []string{"a"}},
+			},
+			err: "dependency cycle: a -> b -> c -> a",
+		},
+		{
+			name: "self dependency",
+			components: []Component{
+				{Name: "a", DependsOn: []string{"a"}},
+			},
+			err: "dependency cycle: a -> a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry(time.Second)
+			for _, c := range tt.components {
+				r.Register(c)
+			}
+			ordered, err := r.Order()
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("Order() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Order() error = %v", err)
+			}
+			if got := names(ordered); got != tt.order {
+				t.Errorf("Order() = %s, want %s", got, tt.order)
+			}
+		})
+	}
+}
+
+// recorder records the components run and shut down, in order
+type recorder struct {
+	events []string
+}
+
+func (rec *recorder) component(name string, runErr, shutdownErr error, dependsOn ...string) Component {
+	return Component{
+		Name:      name,
+		DependsOn: dependsOn,
+		Run: func() (func(context.Context) error, error) {
+			if runErr != nil {
+				return nil, runErr
+			}
+			rec.events = append(rec.events, "run "+name)
+			return func(context.Context) error {
+				rec.events = append(rec.events, "stop "+name)
+				return shutdownErr
+			}, nil
+		},
+	}
+}
+
+func TestStartStop(t *testing.T) {
+	rec := &recorder{}
+	r := NewRegistry(time.Second)
+	r.Register(rec.component("server", nil, nil, "client"))
+	r.Register(rec.component("client", nil, errors.New("still connected"), "tracing"))
+	r.Register(rec.component("tracing", nil, nil))
+
+	report, err := r.Start(time.Second)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if len(report) != 3 || report[0].Name != "tracing" || report[2].Name != "server" {
+		t.Errorf("report = %v, want tracing, client, server", report)
+	}
+
+	errs := r.Stop(context.Background())
+	if len(errs) != 1 || errs[0].Error() != "client shutdown failed: still connected" {
+		t.Errorf("Stop() = %v, want the client's error", errs)
+	}
+	want := "run tracing,run client,run server,stop server,stop client,stop tracing"
+	if got := strings.Join(rec.events, ","); got != want {
+		t.Errorf("events = %s, want %s", got, want)
+	}
+	if errs := r.Stop(context.Background()); errs != nil {
+		t.Errorf("second Stop() = %v, want nothing to stop", errs)
+	}
+}
+
+func TestStartFailureStopsStarted(t *testing.T) {
+	rec := &recorder{}
+	r := NewRegistry(time.Second)
+	r.Register(rec.component("tracing", nil, nil))
+	r.Register(rec.component("client", nil, nil, "tracing"))
+	r.Register(rec.component("server", errors.New("port in use"), nil, "client"))
+
+	report, err := r.Start(time.Second)
+	if err == nil || err.Error() != "server failed: port in use" {
+		t.Fatalf("Start() error = %v, want the server's error", err)
+	}
+	if len(report) != 2 {
+		t.Errorf("report = %v, want the two components that started", report)
+	}
+	want := "run tracing,run client,stop client,stop tracing"
+	if got := strings.Join(rec.events, ","); got != want {
+		t.Errorf("events = %s, want %s", got, want)
+	}
+}
+
+func TestStartTimeout(t *testing.T) {
+	stopped := make(chan struct{})
+	release := make(chan struct{})
+	r := NewRegistry(time.Hour)
+	r.Register(Component{
+		Name:         "slow",
+		StartTimeout: 10 * time.Millisecond,
+		Run: func() (func(context.Context) error, error) {
+			<-release
+			return func(context.Context) error {
+				close(stopped)
+				return nil
+			}, nil
+		},
+	})
+
+	_, err := r.Start(time.Second)
+	if err == nil || err.Error() != "slow failed: timed out after 10ms" {
+		t.Fatalf("Start() error = %v, want a timeout", err)
+	}
+	// a component that starts after timing out is shut down straight away
+	close(release)
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("component that started late was not shut down")
+	}
+}
+
+func TestStartupReportString(t *testing.T) {
+	report := StartupReport{
+		{Name: "tracing", Duration: time.Second},
+		{Name: "server", Duration: 2 * time.Second},
+	}
+	got := report.String()
+	for _, want := range []string{"Startup report:", "tracing", "server", "total", "3s"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %q, want it to contain %q", got, want)
+		}
+	}
+}
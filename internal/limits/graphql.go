@@ -0,0 +1,206 @@
+package limits
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	// costDirective annotates what resolving a field costs
+	costDirective = "cost"
+	// ceiling caps the measured complexity so huge page sizes cannot overflow it
+	ceiling = math.MaxInt32
+)
+
+// cost is what resolving a field costs, read from its @cost directive
+type cost struct {
+	complexity  int
+	multipliers []string
+}
+
+// GraphQL returns the gqlgen extension rejecting operations that are deeper or more
+// complex than the limits before anything is resolved. A field costs its @cost
+// complexity, or 1, plus what it selects counted once per item of its page size.
+// Page sizes that are not given count as defaultPageSize.
+func GraphQL(cfg config.Limits, defaultPageSize int) graphql.HandlerExtension {
+	return &graphqlExtension{
+		maxDepth:        cfg.MaxDepth,
+		maxComplexity:   cfg.MaxComplexity,
+		defaultPageSize: defaultPageSize,
+	}
+}
+
+type graphqlExtension struct {
+	maxDepth        int
+	maxComplexity   int
+	defaultPageSize int
+	// costs are keyed by type and field name, such as Query.posts
+	costs map[string]cost
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = &graphqlExtension{}
+
+// ExtensionName names the extension
+func (e *graphqlExtension) ExtensionName() string {
+	return "Limits"
+}
+
+// Validate reads the cost of each field from the schema
+func (e *graphqlExtension) Validate(schema graphql.ExecutableSchema) error {
+	e.costs = map[string]cost{}
+	for _, def := range schema.Schema().Types {
+		for _, field := range def.Fields {
+			directive := field.Directives.ForName(costDirective)
+			if directive == nil {
+				continue
+			}
+			c, err := parseCost(directive)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read the cost of %s.%s", def.Name, field.Name)
+			}
+			e.costs[def.Name+"."+field.Name] = c
+		}
+	}
+	return nil
+}
+
+func parseCost(directive *ast.Directive) (cost, error) {
+	var c cost
+	arg := directive.Arguments.ForName("complexity")
+	if arg == nil {
+		return c, errors.New("complexity is required")
+	}
+	complexity, err := arg.Value.Value(nil)
+	if err != nil {
+		return c, errors.Wrap(err, "failed to read complexity")
+	}
+	n, ok := complexity.(int64)
+	if !ok || n < 0 {
+		return c, errors.New("complexity must be a positive int")
+	}
+	c.complexity = int(n)
+	if arg := directive.Arguments.ForName("multipliers"); arg != nil {
+		multipliers, err := arg.Value.Value(nil)
+		if err != nil {
+			return c, errors.Wrap(err, "failed to read multipliers")
+		}
+		list, _ := multipliers.([]interface{})
+		for _, m := range list {
+			name, ok := m.(string)
+			if !ok {
+				return c, errors.New("multipliers must be argument names")
+			}
+			c.multipliers = append(c.multipliers, name)
+		}
+	}
+	return c, nil
+}
+
+// MutateOperationContext measures the operation, rejecting it if it is over a limit
+func (e *graphqlExtension) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	depth, complexity := e.measure(rc, rc.Operation.SelectionSet)
+	if e.maxDepth > 0 && depth > e.maxDepth {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, e.maxDepth)
+		err.Extensions = map[string]interface{}{"code": "DEPTH_LIMIT_EXCEEDED"}
+		return err
+	}
+	if e.maxComplexity > 0 && complexity > e.maxComplexity {
+		err := gqlerror.Errorf("operation has complexity %d, which exceeds the limit of %d", complexity, e.maxComplexity)
+		err.Extensions = map[string]interface{}{"code": "COMPLEXITY_LIMIT_EXCEEDED"}
+		return err
+	}
+	return nil
+}
+
+// measure gets the depth and complexity of the selections, introspection is not counted
+func (e *graphqlExtension) measure(rc *graphql.OperationContext, set ast.SelectionSet) (int, int) {
+	depth, complexity := 0, 0
+	for _, selection := range set {
+		var d, c int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name, "__") {
+				continue
+			}
+			childDepth, childComplexity := e.measure(rc, selection.SelectionSet)
+			d = childDepth + 1
+			c = e.fieldComplexity(rc, selection, childComplexity)
+		case *ast.FragmentSpread:
+			if selection.Definition != nil {
+				d, c = e.measure(rc, selection.Definition.SelectionSet)
+			}
+		case *ast.InlineFragment:
+			d, c = e.measure(rc, selection.SelectionSet)
+		}
+		if d > depth {
+			depth = d
+		}
+		complexity = add(complexity, c)
+	}
+	return depth, complexity
+}
+
+// fieldComplexity gets the complexity of the field given the complexity of what it selects
+func (e *graphqlExtension) fieldComplexity(rc *graphql.OperationContext, field *ast.Field, childComplexity int) int {
+	c := cost{complexity: 1}
+	if field.ObjectDefinition != nil {
+		if annotated, ok := e.costs[field.ObjectDefinition.Name+"."+field.Name]; ok {
+			c = annotated
+		}
+	}
+	if len(c.multipliers) == 0 {
+		return add(c.complexity, childComplexity)
+	}
+	pageSize := e.defaultPageSize
+	args := field.ArgumentMap(rc.Variables)
+	for _, name := range c.multipliers {
+		if n, ok := toInt(args[name]); ok {
+			pageSize = n
+			break
+		}
+	}
+	return add(c.complexity, mul(pageSize, childComplexity))
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return int(i), err == nil
+	}
+	return 0, false
+}
+
+func add(a, b int) int {
+	if a > ceiling-b {
+		return ceiling
+	}
+	return a + b
+}
+
+func mul(a, b int) int {
+	if a < 0 || b < 0 {
+		return 0
+	}
+	if a != 0 && b > ceiling/a {
+		return ceiling
+	}
+	return a * b
+}
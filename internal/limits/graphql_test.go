@@ -0,0 +1,209 @@
+package limits
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+const testSchema = `
+directive @cost(complexity: Int!, multipliers: [String!]) on FIELD_DEFINITION
+
+type Query {
+  post: Post
+  posts(first: Int, last: Int): [Post] @cost(complexity: 3, multipliers: ["first", "last"])
+}
+
+type Post {
+  title: String
+  author: User @cost(complexity: 2)
+}
+
+type User {
+  name: String
+  posts(first: Int): [Post] @cost(complexity: 1, multipliers: ["first"])
+}
+`
+
+const testDefaultPageSize = 10
+
+// executableSchema is just enough of a schema for the extension to read costs from
+type executableSchema struct {
+	schema *ast.Schema
+}
+
+func (s executableSchema) Schema() *ast.Schema {
+	return s.schema
+}
+
+func (s executableSchema) Complexity(typeName, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+	return 0, false
+}
+
+func (s executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
+	return nil
+}
+
+func newExtension(t *testing.T, cfg config.Limits) (*graphqlExtension, *ast.Schema) {
+	t.Helper()
+	schema := gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphqls", Input: testSchema})
+	e := GraphQL(cfg, testDefaultPageSize).(*graphqlExtension)
+	if err := e.Validate(executableSchema{schema: schema}); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	return e, schema
+}
+
+// operation parses and validates the query the way gqlgen does before measuring it
+func operation(t *testing.T, schema *ast.Schema, query string, variables map[string]interface{}) *graphql.OperationContext {
+	t.Helper()
+	doc, errs := gqlparser.LoadQuery(schema, query)
+	if len(errs) > 0 {
+		t.Fatalf("LoadQuery() errors = %v", errs)
+	}
+	return &graphql.OperationContext{Operation: doc.Operations[0], Variables: variables}
+}
+
+func TestMeasure(t *testing.T) {
+	e, schema := newExtension(t, config.Limits{})
+	tests := []struct {
+		name           string
+		query          string
+		variables      map[string]interface{}
+		wantDepth      int
+		wantComplexity int
+	}{
+		{name: "plain fields", query: `{ post { title } }`, wantDepth: 2, wantComplexity: 2},
+		{name: "annotated field", query: `{ post { author { name } } }`, wantDepth: 3, wantComplexity: 4},
+		{name: "multiplier literal", query: `{ posts(first: 5) { title author { name } } }`, wantDepth: 3, wantComplexity: 3 + 5*4},
+		{name: "second multiplier", query: `{ posts(last: 2) { title } }`, wantDepth: 2, wantComplexity: 3 + 2},
+		{name: "default page size", query: `{ posts { title } }`, wantDepth: 2, wantComplexity: 3 + testDefaultPageSize},
+		{name: "multiplier variable", query: `query($n: Int) { posts(first: $n) { title } }`,
+			variables: map[string]interface{}{"n": int64(4)}, wantDepth: 2, wantComplexity: 3 + 4},
+		{name: "multiplier json variable", query: `query($n: Int) { posts(first: $n) { title } }`,
+			variables: map[string]interface{}{"n": json.Number("6")}, wantDepth: 2, wantComplexity: 3 + 6},
+		{name: "multiplier variable not given", query: `query($n: Int) { posts(first: $n) { title } }`,
+			wantDepth: 2, wantComplexity: 3 + testDefaultPageSize},
+		{name: "nested multipliers", query: `{ posts(first: 100) { author { posts(first: 100) { title } } } }`,
+			wantDepth: 4, wantComplexity: 3 + 100*(2+1+100)},
+		{name: "fragment spread", query: `{ ...F } fragment F on Query { posts(first: 2) { ...P } } fragment P on Post { title }`,
+			wantDepth: 2, wantComplexity: 3 + 2},
+		{name: "inline fragment", query: `{ post { ... on Post { title } } }`, wantDepth: 2, wantComplexity: 2},
+		{name: "introspection skipped", query: `{ __typename post { __typename title } }`, wantDepth: 2, wantComplexity: 2},
+		{name: "only introspection", query: `{ __schema { types { name } } }`, wantDepth: 0, wantComplexity: 0},
+		{name: "saturates", query: `{ posts(first: 2147483647) { author { name } } }`, wantDepth: 3, wantComplexity: ceiling},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := operation(t, schema, tt.query, tt.variables)
+			depth, complexity := e.measure(rc, rc.Operation.SelectionSet)
+			if depth != tt.wantDepth || complexity != tt.wantComplexity {
+				t.Errorf("measure() = %d, %d, want %d, %d", depth, complexity, tt.wantDepth, tt.wantComplexity)
+			}
+		})
+	}
+}
+
+func TestMutateOperationContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.Limits
+		query    string
+		wantCode string
+	}{
+		{name: "within limits", cfg: config.Limits{MaxDepth: 3, MaxComplexity: 23}, query: `{ posts(first: 5) { title author { name } } }`},
+		{name: "no limits", query: `{ posts(first: 2147483647) { author { name } } }`},
+		{name: "too deep", cfg: config.Limits{MaxDepth: 2}, query: `{ post { author { name } } }`, wantCode: "DEPTH_LIMIT_EXCEEDED"},
+		{name: "too complex", cfg: config.Limits{MaxComplexity: 22}, query: `{ posts(first: 5) { title author { name } } }`, wantCode: "COMPLEXITY_LIMIT_EXCEEDED"},
+		{name: "depth checked first", cfg: config.Limits{MaxDepth: 1, MaxComplexity: 1}, query: `{ post { title } }`, wantCode: "DEPTH_LIMIT_EXCEEDED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, schema := newExtension(t, tt.cfg)
+			err := e.MutateOperationContext(context.Background(), operation(t, schema, tt.query, nil))
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("MutateOperationContext() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Extensions["code"] != tt.wantCode {
+				t.Errorf("MutateOperationContext() error = %v, want %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestParseCost(t *testing.T) {
+	tests := []struct {
+		name            string
+		directive       string
+		wantComplexity  int
+		wantMultipliers []string
+		wantErr         bool
+	}{
+		{name: "complexity", directive: `@cost(complexity: 2)`, wantComplexity: 2},
+		{name: "multipliers", directive: `@cost(complexity: 1, multipliers: ["first", "last"])`, wantComplexity: 1, wantMultipliers: []string{"first", "last"}},
+		{name: "zero", directive: `@cost(complexity: 0)`},
+		{name: "no complexity", directive: `@cost(multipliers: ["first"])`, wantErr: true},
+		{name: "negative complexity", directive: `@cost(complexity: -1)`, wantErr: true},
+		{name: "string complexity", directive: `@cost(complexity: "2")`, wantErr: true},
+		{name: "float complexity", directive: `@cost(complexity: 1.5)`, wantErr: true},
+		{name: "numeric multiplier", directive: `@cost(complexity: 1, multipliers: [1])`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// parsed without validating, so directives the schema would refuse still reach parseCost
+			doc, err := parser.ParseSchema(&ast.Source{Input: "type Query { field: Int " + tt.directive + " }"})
+			if err != nil {
+				t.Fatalf("ParseSchema() error = %v", err)
+			}
+			c, costErr := parseCost(doc.Definitions[0].Fields[0].Directives.ForName(costDirective))
+			if tt.wantErr {
+				if costErr == nil {
+					t.Errorf("parseCost() = %+v, want an error", c)
+				}
+				return
+			}
+			if costErr != nil {
+				t.Fatalf("parseCost() error = %v", costErr)
+			}
+			if c.complexity != tt.wantComplexity || len(c.multipliers) != len(tt.wantMultipliers) {
+				t.Fatalf("parseCost() = %+v, want complexity %d multipliers %v", c, tt.wantComplexity, tt.wantMultipliers)
+			}
+			for i := range c.multipliers {
+				if c.multipliers[i] != tt.wantMultipliers[i] {
+					t.Errorf("parseCost() multipliers = %v, want %v", c.multipliers, tt.wantMultipliers)
+				}
+			}
+		})
+	}
+}
+
+func TestSaturatingArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{name: "add", got: add(1, 2), want: 3},
+		{name: "add to ceiling", got: add(ceiling-1, 1), want: ceiling},
+		{name: "add over ceiling", got: add(ceiling, ceiling), want: ceiling},
+		{name: "mul", got: mul(3, 4), want: 12},
+		{name: "mul by zero", got: mul(0, ceiling), want: 0},
+		{name: "mul negative page size", got: mul(-5, 4), want: 0},
+		{name: "mul under ceiling", got: mul(2, ceiling/2), want: ceiling - 1},
+		{name: "mul over ceiling", got: mul(ceiling, 2), want: ceiling},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
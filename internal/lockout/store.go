@@ -0,0 +1,66 @@
+package lockout
+
+import (
+	"sync"
+	"time"
+)
+
+// Attempts are the failed logins recorded for a key
+type Attempts struct {
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+	// Expires is when the attempts can be forgotten
+	Expires time.Time
+}
+
+// Store keeps track of the failed attempts for each key
+type Store interface {
+	Get(key string) (Attempts, error)
+	Update(key string, update func(Attempts) Attempts) (Attempts, error)
+	Delete(key string) error
+}
+
+type memoryStore struct {
+	mu       sync.Mutex
+	attempts map[string]Attempts
+	now      func() time.Time
+}
+
+// NewMemoryStore news up an in memory attempts store
+func NewMemoryStore() Store {
+	return &memoryStore{
+		attempts: map[string]Attempts{},
+		now:      time.Now,
+	}
+}
+
+// Get gets the attempts for the key, the zero value if there are none
+func (s *memoryStore) Get(key string) (Attempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attempts[key], nil
+}
+
+// Update atomically replaces the attempts for the key, clearing out any that have expired
+func (s *memoryStore) Update(key string, update func(Attempts) Attempts) (Attempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for k, a := range s.attempts {
+		if !now.Before(a.Expires) {
+			delete(s.attempts, k)
+		}
+	}
+	a := update(s.attempts[key])
+	s.attempts[key] = a
+	return a, nil
+}
+
+// Delete forgets the attempts for the key
+func (s *memoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.attempts, key)
+	return nil
+}
@@ -0,0 +1,179 @@
+package lockout
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+)
+
+const (
+	// Account is the kind of key for the username or email logged in with
+	Account = "account"
+	// IP is the kind of key for the client ip logged in from
+	IP = "ip"
+)
+
+// Status is whether a login may be attempted
+type Status struct {
+	// Locked is set while the account or ip is locked out
+	Locked bool
+	// RetryAfter is how long until a login may be attempted, zero if one may be attempted now
+	RetryAfter time.Duration
+}
+
+// Allowed reports whether a login may be attempted now
+func (s Status) Allowed() bool {
+	return !s.Locked && s.RetryAfter <= 0
+}
+
+// Lockout is an account or ip that was locked out
+type Lockout struct {
+	Kind     string
+	Key      string
+	Failures int
+	Until    time.Time
+}
+
+// Tracker tracks failed logins for each account and each client ip. Every failure
+// doubles the delay before another login may be attempted, and enough of them lock
+// the account or ip out for a while.
+//
+// Accounts are tracked by what was logged in with whether or not it exists, so how
+// logins are delayed and locked out says nothing about which accounts exist.
+type Tracker struct {
+	cfg   config.Lockout
+	store Store
+	now   func() time.Time
+}
+
+// NewTracker news up a tracker with the configured thresholds, keeping its attempts in the store
+func NewTracker(cfg config.Lockout, store Store) (*Tracker, error) {
+	if cfg.MaxFailures <= 0 || cfg.MaxIPFailures <= 0 {
+		return nil, errors.New("lockout requires positive max failures")
+	}
+	if cfg.Duration <= 0 || cfg.Window <= 0 {
+		return nil, errors.New("lockout requires a positive duration and window")
+	}
+	if cfg.BaseDelay < 0 || cfg.MaxDelay < cfg.BaseDelay {
+		return nil, errors.New("lockout requires a max delay of at least the base delay")
+	}
+	return &Tracker{
+		cfg:   cfg,
+		store: store,
+		now:   time.Now,
+	}, nil
+}
+
+// Check reports whether a login to the account from the ip may be attempted now
+func (t *Tracker) Check(account, ip string) (Status, error) {
+	now := t.now()
+	var status Status
+	for _, k := range t.keys(account, ip) {
+		a, err := t.store.Get(k.key)
+		if err != nil {
+			return Status{}, errors.Wrapf(err, "failed to get failed logins for %s", k.kind)
+		}
+		a = t.current(a, now)
+		if now.Before(a.LockedUntil) {
+			status.Locked = true
+			status.RetryAfter = maxDuration(status.RetryAfter, a.LockedUntil.Sub(now))
+			continue
+		}
+		if a.Failures > 0 {
+			status.RetryAfter = maxDuration(status.RetryAfter, a.LastFailure.Add(t.delay(a.Failures)).Sub(now))
+		}
+	}
+	return status, nil
+}
+
+// Fail records a failed login to the account from the ip, returning what it locked out
+func (t *Tracker) Fail(account, ip string) ([]Lockout, error) {
+	now := t.now()
+	var lockouts []Lockout
+	for _, k := range t.keys(account, ip) {
+		locked := false
+		a, err := t.store.Update(k.key, func(a Attempts) Attempts {
+			a = t.current(a, now)
+			a.Failures++
+			a.LastFailure = now
+			if a.Failures >= k.maxFailures && !now.Before(a.LockedUntil) {
+				a.LockedUntil = now.Add(t.cfg.Duration)
+				locked = true
+			}
+			a.Expires = now.Add(t.cfg.Window)
+			if a.Expires.Before(a.LockedUntil) {
+				a.Expires = a.LockedUntil
+			}
+			return a
+		})
+		if err != nil {
+			return lockouts, errors.Wrapf(err, "failed to record failed login for %s", k.kind)
+		}
+		if locked {
+			lockouts = append(lockouts, Lockout{Kind: k.kind, Key: k.value, Failures: a.Failures, Until: a.LockedUntil})
+		}
+	}
+	return lockouts, nil
+}
+
+// Succeed forgets the failed logins to the account. Those from the ip are kept, as
+// otherwise logging in to one account would let an ip keep guessing at others.
+func (t *Tracker) Succeed(account string) error {
+	account = normalize(account)
+	if account == "" {
+		return nil
+	}
+	return errors.Wrap(t.store.Delete(Account+":"+account), "failed to forget failed logins for account")
+}
+
+// current drops the failures that have been forgotten by now
+func (t *Tracker) current(a Attempts, now time.Time) Attempts {
+	if !now.Before(a.Expires) {
+		return Attempts{}
+	}
+	return a
+}
+
+// delay is how long after the last of the failures a login may be attempted
+func (t *Tracker) delay(failures int) time.Duration {
+	d := t.cfg.BaseDelay
+	for i := 1; i < failures && d < t.cfg.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > t.cfg.MaxDelay {
+		d = t.cfg.MaxDelay
+	}
+	return d
+}
+
+type trackedKey struct {
+	kind        string
+	value       string
+	key         string
+	maxFailures int
+}
+
+// keys are the keys tracked for a login, leaving out any that are unknown
+func (t *Tracker) keys(account, ip string) []trackedKey {
+	var keys []trackedKey
+	if account = normalize(account); account != "" {
+		keys = append(keys, trackedKey{kind: Account, value: account, key: Account + ":" + account, maxFailures: t.cfg.MaxFailures})
+	}
+	if ip != "" {
+		keys = append(keys, trackedKey{kind: IP, value: ip, key: IP + ":" + ip, maxFailures: t.cfg.MaxIPFailures})
+	}
+	return keys
+}
+
+func normalize(account string) string {
+	return strings.ToLower(strings.TrimSpace(account))
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,168 @@
+package lockout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/srcabl/gateway/internal/config"
+)
+
+var testConfig = config.Lockout{
+	MaxFailures:   3,
+	MaxIPFailures: 5,
+	BaseDelay:     time.Second,
+	MaxDelay:      4 * time.Second,
+	Duration:      15 * time.Minute,
+	Window:        time.Hour,
+}
+
+const (
+	fail    = "fail"
+	check   = "check"
+	succeed = "succeed"
+)
+
+// step is one action against the tracker, taken after waiting
+type step struct {
+	after   time.Duration
+	action  string
+	account string
+	ip      string
+	// wantStatus is checked for checks, wantLocked for failures
+	wantStatus Status
+	wantLocked []string
+}
+
+func TestTracker(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{name: "delay doubles with each failure", steps: []step{
+			{action: check, account: "alice", ip: "1.1.1.1"},
+			{action: fail, account: "alice", ip: "1.1.1.1"},
+			{action: check, account: "alice", ip: "1.1.1.1", wantStatus: Status{RetryAfter: time.Second}},
+			{after: time.Second, action: check, account: "alice", ip: "1.1.1.1"},
+			{action: fail, account: "alice", ip: "1.1.1.1"},
+			{action: check, account: "alice", ip: "1.1.1.1", wantStatus: Status{RetryAfter: 2 * time.Second}},
+		}},
+		{name: "accounts are normalized", steps: []step{
+			{action: fail, account: " Alice ", ip: "1.1.1.1"},
+			{action: check, account: "alice", ip: "2.2.2.2", wantStatus: Status{RetryAfter: time.Second}},
+		}},
+		{name: "account locks out", steps: []step{
+			{action: fail, account: "alice", ip: "1.1.1.1"},
+			{action: fail, account: "alice", ip: "2.2.2.2"},
+			{action: fail, account: "alice", ip: "3.3.3.3", wantLocked: []string{Account}},
+			{action: check, account: "alice", ip: "4.4.4.4", wantStatus: Status{Locked: true, RetryAfter: 15 * time.Minute}},
+			{action: check, account: "bob", ip: "3.3.3.3", wantStatus: Status{RetryAfter: time.Second}},
+		}},
+		{name: "ip locks out across accounts", steps: []step{
+			{action: fail, account: "a", ip: "1.1.1.1"},
+			{action: fail, account: "b", ip: "1.1.1.1"},
+			{action: fail, account: "c", ip: "1.1.1.1"},
+			{action: fail, account: "d", ip: "1.1.1.1"},
+			{action: check, account: "e", ip: "1.1.1.1", wantStatus: Status{RetryAfter: 4 * time.Second}},
+			{action: fail, account: "e", ip: "1.1.1.1", wantLocked: []string{IP}},
+			{action: check, account: "f", ip: "1.1.1.1", wantStatus: Status{Locked: true, RetryAfter: 15 * time.Minute}},
+			{action: check, account: "f", ip: "2.2.2.2"},
+		}},
+		{name: "delay is capped", steps: []step{
+			{action: fail, ip: "1.1.1.1"},
+			{action: fail, ip: "1.1.1.1"},
+			{action: fail, ip: "1.1.1.1"},
+			{action: fail, ip: "1.1.1.1"},
+			{action: check, ip: "1.1.1.1", wantStatus: Status{RetryAfter: 4 * time.Second}},
+		}},
+		{name: "success forgets the account but not the ip", steps: []step{
+			{action: fail, account: "alice", ip: "1.1.1.1"},
+			{action: fail, account: "alice", ip: "1.1.1.1"},
+			{action: succeed, account: "alice"},
+			{action: check, account: "alice", ip: "2.2.2.2"},
+			{action: check, account: "bob", ip: "1.1.1.1", wantStatus: Status{RetryAfter: 2 * time.Second}},
+		}},
+		{name: "lockout ends and locks again", steps: []step{
+			{action: fail, account: "alice", ip: "1.1.1.1"},
+			{action: fail, account: "alice", ip: "1.1.1.1"},
+			{action: fail, account: "alice", ip: "1.1.1.1", wantLocked: []string{Account}},
+			{after: 15 * time.Minute, action: check, account: "alice", ip: "1.1.1.1"},
+			{action: fail, account: "alice", ip: "1.1.1.1", wantLocked: []string{Account}},
+		}},
+		{name: "failures are forgotten after the window", steps: []step{
+			{action: fail, account: "alice", ip: "1.1.1.1"},
+			{action: fail, account: "alice", ip: "1.1.1.1"},
+			{after: time.Hour, action: check, account: "alice", ip: "1.1.1.1"},
+			{action: fail, account: "alice", ip: "1.1.1.1"},
+			{action: check, account: "alice", ip: "1.1.1.1", wantStatus: Status{RetryAfter: time.Second}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore().(*memoryStore)
+			tracker, err := NewTracker(testConfig, store)
+			if err != nil {
+				t.Fatalf("NewTracker() error = %v", err)
+			}
+			now := time.Now()
+			tracker.now = func() time.Time { return now }
+			store.now = tracker.now
+
+			for i, s := range tt.steps {
+				now = now.Add(s.after)
+				switch s.action {
+				case check:
+					status, err := tracker.Check(s.account, s.ip)
+					if err != nil {
+						t.Fatalf("step %d: Check() error = %v", i, err)
+					}
+					if status != s.wantStatus {
+						t.Errorf("step %d: Check() = %+v, want %+v", i, status, s.wantStatus)
+					}
+					if status.Allowed() != (s.wantStatus == Status{}) {
+						t.Errorf("step %d: Allowed() = %v", i, status.Allowed())
+					}
+				case fail:
+					lockouts, err := tracker.Fail(s.account, s.ip)
+					if err != nil {
+						t.Fatalf("step %d: Fail() error = %v", i, err)
+					}
+					if len(lockouts) != len(s.wantLocked) {
+						t.Fatalf("step %d: Fail() locked out %+v, want %v", i, lockouts, s.wantLocked)
+					}
+					for j, lockout := range lockouts {
+						if lockout.Kind != s.wantLocked[j] || !lockout.Until.Equal(now.Add(testConfig.Duration)) {
+							t.Errorf("step %d: Fail() locked out %+v, want %s until %v", i, lockout, s.wantLocked[j], now.Add(testConfig.Duration))
+						}
+					}
+				case succeed:
+					if err := tracker.Succeed(s.account); err != nil {
+						t.Fatalf("step %d: Succeed() error = %v", i, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNewTrackerRejectsBadConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(*config.Lockout)
+	}{
+		{name: "no max failures", update: func(c *config.Lockout) { c.MaxFailures = 0 }},
+		{name: "no max ip failures", update: func(c *config.Lockout) { c.MaxIPFailures = 0 }},
+		{name: "no duration", update: func(c *config.Lockout) { c.Duration = 0 }},
+		{name: "no window", update: func(c *config.Lockout) { c.Window = 0 }},
+		{name: "negative base delay", update: func(c *config.Lockout) { c.BaseDelay = -time.Second }},
+		{name: "max delay under base delay", update: func(c *config.Lockout) { c.MaxDelay = time.Millisecond }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig
+			tt.update(&cfg)
+			if _, err := NewTracker(cfg, NewMemoryStore()); err == nil {
+				t.Error("NewTracker() succeeded, want an error")
+			}
+		})
+	}
+}
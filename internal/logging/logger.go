@@ -0,0 +1,124 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/middleware"
+	"github.com/srcabl/gateway/internal/util"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logger is the gateway's structured, leveled logger. Sensitive fields are
+// redacted before they are written and the level can be changed at runtime.
+type Logger struct {
+	*zap.Logger
+	level zap.AtomicLevel
+}
+
+// New news up the logger configured for the gateway
+func New(cfg config.Logging) (*Logger, error) {
+	level := zap.NewAtomicLevel()
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, errors.Wrapf(err, "invalid log level %s", cfg.Level)
+	}
+
+	var zapConfig zap.Config
+	switch cfg.Format {
+	case "json":
+		zapConfig = zap.NewProductionConfig()
+	case "console":
+		zapConfig = zap.NewDevelopmentConfig()
+	default:
+		return nil, errors.Errorf("unknown log format %s", cfg.Format)
+	}
+	zapConfig.Level = level
+	zapConfig.EncoderConfig.TimeKey = "time"
+	zapConfig.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	logger, err := zapConfig.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &redactingCore{Core: core}
+	}))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build the logger")
+	}
+	return &Logger{
+		Logger: logger,
+		level:  level,
+	}, nil
+}
+
+// NewNop news up a logger that discards everything
+func NewNop() *Logger {
+	return &Logger{
+		Logger: zap.NewNop(),
+		level:  zap.NewAtomicLevel(),
+	}
+}
+
+// Named returns a logger for a named part of the gateway
+func (l *Logger) Named(name string) *Logger {
+	return &Logger{
+		Logger: l.Logger.Named(name),
+		level:  l.level,
+	}
+}
+
+// fieldsKey is the context key of the fields every line logged for a request is tagged with
+type fieldsKey struct{}
+
+// InjectFields handles working out the request, trace and user a request is for once,
+// so that every line logged while handling it is tagged with them. It must come after
+// the session and bearer token are injected.
+func InjectFields() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			fields := contextFields(ctx)
+			if userUUID, err := uuid.FromBytes(util.GetUserUUIDFromContext(ctx)); err == nil {
+				fields = append(fields, zap.String("user_id", userUUID.String()))
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, fieldsKey{}, fields)))
+		})
+	}
+}
+
+// Ctx returns a logger that tags every line with the request and user in the context.
+// The user is the one the request came in as, not any it signs in as while handled.
+func (l *Logger) Ctx(ctx context.Context) *zap.Logger {
+	fields, ok := ctx.Value(fieldsKey{}).([]zap.Field)
+	if !ok {
+		fields = contextFields(ctx)
+	}
+	if len(fields) == 0 {
+		return l.Logger
+	}
+	return l.Logger.With(fields...)
+}
+
+// contextFields gets the fields of the request and trace in the context
+func contextFields(ctx context.Context) []zap.Field {
+	var fields []zap.Field
+	if id := middleware.GetRequestID(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+	}
+	return fields
+}
+
+// SetLevel changes the level logged at runtime
+func (l *Logger) SetLevel(level zapcore.Level) {
+	l.level.SetLevel(level)
+}
+
+// LevelHandler serves the current level on GET and changes it on PUT
+func (l *Logger) LevelHandler() http.Handler {
+	return l.level
+}
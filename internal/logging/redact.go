@@ -0,0 +1,183 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// redacted replaces the value of every sensitive field
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are the parts of a field name that mark it as sensitive
+var sensitiveKeys = []string{
+	"password",
+	"passwd",
+	"hash",
+	"secret",
+	"token",
+	"session",
+	"cookie",
+	"authorization",
+	"credential",
+}
+
+// sensitiveText matches a sensitive key followed by its value in free text such as error
+// messages, like password=hunter2 or "token": "abc", and bearer credentials
+var sensitiveText = regexp.MustCompile(`(?i)([\w-]*(?:` + strings.Join(sensitiveKeys, "|") + `)[\w-]*["']?\s*[:=]\s*(?:bearer\s+)?)("[^"]*"|'[^']*'|[^\s,;&)]+)|(bearer\s+)[\w.~+/=-]+`)
+
+// isSensitive reports whether a field name, in any casing, names a sensitive value
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactingCore redacts sensitive fields before handing them to the wrapped core
+type redactingCore struct {
+	zapcore.Core
+}
+
+// With adds redacted fields to the core
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(redactFields(fields))}
+}
+
+// Check adds this core, rather than the wrapped one, to the entry so that Write redacts
+func (c *redactingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write writes the entry with its message and fields redacted
+func (c *redactingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = redactText(ent.Message)
+	return c.Core.Write(ent, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		out[i] = redactField(f)
+	}
+	return out
+}
+
+// redactField redacts a sensitive field, and the sensitive keys within structs, objects
+// and arrays. Error text has sensitive values that follow their key redacted, other
+// free text such as plain string fields is written as it is.
+func redactField(f zapcore.Field) zapcore.Field {
+	if isSensitive(f.Key) {
+		return zap.String(f.Key, redacted)
+	}
+	switch f.Type {
+	case zapcore.ObjectMarshalerType:
+		enc := zapcore.NewMapObjectEncoder()
+		if err := enc.AddObject(f.Key, f.Interface.(zapcore.ObjectMarshaler)); err != nil {
+			return zap.String(f.Key, redacted)
+		}
+		return zap.Reflect(f.Key, redactValue(enc.Fields[f.Key]))
+	case zapcore.ArrayMarshalerType:
+		enc := zapcore.NewMapObjectEncoder()
+		if err := enc.AddArray(f.Key, f.Interface.(zapcore.ArrayMarshaler)); err != nil {
+			return zap.String(f.Key, redacted)
+		}
+		return zap.Reflect(f.Key, redactValue(enc.Fields[f.Key]))
+	case zapcore.ErrorType:
+		err, ok := f.Interface.(error)
+		if !ok {
+			return f
+		}
+		return zap.NamedError(f.Key, redactedError{
+			msg:     redactText(err.Error()),
+			verbose: redactText(fmt.Sprintf("%+v", err)),
+		})
+	case zapcore.ReflectType, zapcore.StringerType:
+		// structs such as grpc requests are logged with their own sensitive fields redacted
+		if !isStruct(f.Interface) {
+			return f
+		}
+		raw, err := json.Marshal(f.Interface)
+		if err != nil {
+			return zap.String(f.Key, redacted)
+		}
+		var v interface{}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return zap.String(f.Key, redacted)
+		}
+		return zap.Reflect(f.Key, redactValue(v))
+	}
+	return f
+}
+
+// redactText redacts the sensitive values that follow their key in free text
+func redactText(s string) string {
+	return sensitiveText.ReplaceAllStringFunc(s, func(match string) string {
+		parts := sensitiveText.FindStringSubmatch(match)
+		if parts[3] != "" {
+			return parts[3] + redacted
+		}
+		return parts[1] + redacted
+	})
+}
+
+// redactedError is an error whose text has been redacted, keeping the verbose text
+// of errors with a stack trace
+type redactedError struct {
+	msg     string
+	verbose string
+}
+
+func (e redactedError) Error() string {
+	return e.msg
+}
+
+// Format writes the verbose text for %+v, as zap does for errors with a stack trace
+func (e redactedError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprint(s, e.verbose)
+		return
+	}
+	fmt.Fprint(s, e.msg)
+}
+
+func isStruct(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t != nil && (t.Kind() == reflect.Struct || t.Kind() == reflect.Map || t.Kind() == reflect.Slice)
+}
+
+// redactValue walks a decoded json value redacting every sensitive key
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if isSensitive(k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(val)
+		}
+		return v
+	case []interface{}:
+		for i, val := range v {
+			v[i] = redactValue(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
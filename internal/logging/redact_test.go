@@ -0,0 +1,117 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// observe logs through the redacting core into an observer
+func observe() (*zap.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return zap.New(&redactingCore{Core: core}), logs
+}
+
+type credentials struct {
+	Username string
+	Password string
+	Session  struct{ ID string }
+}
+
+func TestRedactFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		field zap.Field
+		key   string
+		want  interface{}
+	}{
+		{name: "password", field: zap.String("password", "hunter2"), key: "password", want: redacted},
+		{name: "token in any casing", field: zap.String("resetToken", "abc"), key: "resetToken", want: redacted},
+		{name: "cookie", field: zap.String("Cookie", "session=abc"), key: "Cookie", want: redacted},
+		{name: "authorization", field: zap.String("authorization", "Bearer abc"), key: "authorization", want: redacted},
+		{name: "binary secret", field: zap.Binary("secret", []byte("abc")), key: "secret", want: redacted},
+		{name: "other field", field: zap.String("username", "alice"), key: "username", want: "alice"},
+		{name: "struct", field: zap.Any("req", credentials{Username: "alice", Password: "hunter2"}), key: "req",
+			want: map[string]interface{}{"Username": "alice", "Password": redacted, "Session": redacted}},
+		{name: "object", field: zap.Object("user", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("name", "alice")
+			enc.AddString("password_hash", "xyz")
+			return nil
+		})), key: "user", want: map[string]interface{}{"name": "alice", "password_hash": redacted}},
+		{name: "array", field: zap.Array("users", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+			return enc.AppendObject(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				enc.AddString("token", "abc")
+				return nil
+			}))
+		})), key: "users", want: []interface{}{map[string]interface{}{"token": redacted}}},
+		{name: "error", field: zap.Error(errors.New("login failed for password=hunter2")), key: "error",
+			want: "login failed for password=" + redacted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, logs := observe()
+			logger.Info("logged", tt.field)
+			got := logs.All()[0].ContextMap()[tt.key]
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("%s = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactWithFields(t *testing.T) {
+	logger, logs := observe()
+	logger.With(zap.String("session_id", "abc"), zap.String("request_id", "r1")).Info("logged")
+	fields := logs.All()[0].ContextMap()
+	if fields["session_id"] != redacted || fields["request_id"] != "r1" {
+		t.Errorf("fields = %v, want only session_id redacted", fields)
+	}
+}
+
+func TestRedactText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "password=hunter2", want: "password=" + redacted},
+		{text: "bad login: password: hunter2, username: alice", want: "bad login: password: " + redacted + ", username: alice"},
+		{text: `{"token": "abc def", "name": "alice"}`, want: `{"token": ` + redacted + `, "name": "alice"}`},
+		{text: "url?reset_token=abc&next=/", want: "url?reset_token=" + redacted + "&next=/"},
+		{text: "Cookie: session=abc", want: "Cookie: " + redacted},
+		{text: "Authorization: Bearer abc.def", want: "Authorization: Bearer " + redacted},
+		{text: "sent bearer abc.def upstream", want: "sent bearer " + redacted + " upstream"},
+		{text: "session expired", want: "session expired"},
+		{text: "nothing to see", want: "nothing to see"},
+	}
+	for _, tt := range tests {
+		if got := redactText(tt.text); got != tt.want {
+			t.Errorf("redactText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRedactMessage(t *testing.T) {
+	logger, logs := observe()
+	logger.Warn("upstream rejected token=abc")
+	if got := logs.All()[0].Message; got != "upstream rejected token="+redacted {
+		t.Errorf("message = %q, want the token redacted", got)
+	}
+}
+
+func TestRedactErrorKeepsStack(t *testing.T) {
+	logger, logs := observe()
+	logger.Error("failed", zap.Error(errors.Wrap(errors.New("secret: abc"), "failed to sign in")))
+	fields := logs.All()[0].ContextMap()
+	verbose, _ := fields["errorVerbose"].(string)
+	if fields["error"] != "failed to sign in: secret: "+redacted {
+		t.Errorf("error = %v, want the secret redacted", fields["error"])
+	}
+	if strings.Contains(verbose, "abc") || !strings.Contains(verbose, "redact_test.go") {
+		t.Errorf("errorVerbose = %q, want the secret redacted and the stack kept", verbose)
+	}
+}
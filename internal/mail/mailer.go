@@ -0,0 +1,90 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/logging"
+	"go.uber.org/zap"
+)
+
+// Message is a mail message
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer defines the behavior of something that can send mail
+type Mailer interface {
+	Send(context.Context, Message) error
+}
+
+// New news up the mailer configured for the gateway
+func New(cfg config.Mailer, logger *logging.Logger) (Mailer, error) {
+	switch cfg.Kind {
+	case "log":
+		return NewLogMailer(logger), nil
+	case "file":
+		if cfg.Path == "" {
+			return nil, errors.New("file mailer requires a path")
+		}
+		return NewFileMailer(cfg.Path), nil
+	default:
+		return nil, errors.Errorf("unknown mailer kind %s", cfg.Kind)
+	}
+}
+
+type logMailer struct {
+	logger *logging.Logger
+}
+
+// NewLogMailer news up a mailer that writes mail to the log, for local development
+func NewLogMailer(logger *logging.Logger) Mailer {
+	return &logMailer{
+		logger: logger.Named("mail"),
+	}
+}
+
+// Send logs the message
+func (m *logMailer) Send(ctx context.Context, msg Message) error {
+	m.logger.Ctx(ctx).Info("sending mail",
+		zap.String("from", msg.From),
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body),
+	)
+	return nil
+}
+
+type fileMailer struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileMailer news up a mailer that appends mail to a file, for local development
+func NewFileMailer(path string) Mailer {
+	return &fileMailer{path: path}
+}
+
+// Send appends the message to the mail file
+func (m *fileMailer) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open mail file %s", m.path)
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "Date: %s\nFrom: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC1123Z), msg.From, msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write mail to %s", m.path)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// otherOperation labels operations that are not known up front
+const otherOperation = "other"
+
+// GraphQL returns the gqlgen extension recording operation and resolver metrics.
+// Operations are only labelled by name when the name is one of the given operations,
+// as clients choose operation names and each one would be a new series.
+func (m *Metrics) GraphQL(operations []string) graphql.HandlerExtension {
+	known := make(map[string]bool, len(operations))
+	for _, name := range operations {
+		known[name] = true
+	}
+	return graphqlExtension{m: m, operations: known}
+}
+
+type graphqlExtension struct {
+	m          *Metrics
+	operations map[string]bool
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = graphqlExtension{}
+
+// ExtensionName names the extension
+func (e graphqlExtension) ExtensionName() string {
+	return "Metrics"
+}
+
+// Validate has nothing to validate against the schema
+func (e graphqlExtension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptResponse records the latency and errors of each operation
+func (e graphqlExtension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	start := time.Now()
+	res := next(ctx)
+
+	operation, kind := "unknown", "unknown"
+	if graphql.HasOperationContext(ctx) {
+		rc := graphql.GetOperationContext(ctx)
+		// the operation is only set once the document has parsed and validated
+		if rc.Operation != nil {
+			operation = otherOperation
+			if e.operations[rc.Operation.Name] {
+				operation = rc.Operation.Name
+			}
+			kind = string(rc.Operation.Operation)
+		}
+		// the operation started before parsing and validation, not when the response was requested
+		if !rc.Stats.OperationStart.IsZero() {
+			start = rc.Stats.OperationStart
+		}
+	}
+	e.m.operationDuration.WithLabelValues(operation, kind).Observe(time.Since(start).Seconds())
+	if res != nil && len(res.Errors) > 0 {
+		e.m.operationErrors.WithLabelValues(operation, kind).Inc()
+	}
+	return res
+}
+
+// InterceptField records the latency and errors of each resolver. Fields that
+// are plain struct reads are skipped to keep the cardinality down.
+func (e graphqlExtension) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+
+	start := time.Now()
+	res, err := next(ctx)
+	e.m.fieldDuration.WithLabelValues(fc.Object, fc.Field.Name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		e.m.fieldErrors.WithLabelValues(fc.Object, fc.Field.Name).Inc()
+	}
+	return res, err
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor records the latency and status code of unary calls to the upstreams
+func (m *Metrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		m.observeGRPC(method, start, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor records the latency and status code of establishing streams to the upstreams
+func (m *Metrics) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		m.observeGRPC(method, start, err)
+		return stream, err
+	}
+}
+
+func (m *Metrics) observeGRPC(fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	m.grpcDuration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+	m.grpcRequests.WithLabelValues(service, method, status.Code(err).String()).Inc()
+}
+
+// splitMethod splits a full grpc method such as /users.UsersService/GetUser into its service and method
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
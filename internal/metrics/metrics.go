@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gateway"
+
+// Metrics holds the prometheus collectors for the gateway. Each Metrics has its
+// own registry so it can be scraped through Handler without a global state.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	operationDuration *prometheus.HistogramVec
+	operationErrors   *prometheus.CounterVec
+	fieldDuration     *prometheus.HistogramVec
+	fieldErrors       *prometheus.CounterVec
+	grpcDuration      *prometheus.HistogramVec
+	grpcRequests      *prometheus.CounterVec
+}
+
+// New news up the gateway metrics
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "graphql",
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of graphql operations.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "type"}),
+		operationErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "graphql",
+			Name:      "operation_errors_total",
+			Help:      "Number of graphql operations that responded with errors.",
+		}, []string{"operation", "type"}),
+		fieldDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "graphql",
+			Name:      "field_duration_seconds",
+			Help:      "Latency of graphql field resolvers.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"object", "field"}),
+		fieldErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "graphql",
+			Name:      "field_errors_total",
+			Help:      "Number of graphql field resolvers that returned an error.",
+		}, []string{"object", "field"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc_client",
+			Name:      "handling_seconds",
+			Help:      "Latency of grpc calls to the upstream services.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"service", "method"}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc_client",
+			Name:      "handled_total",
+			Help:      "Number of grpc calls to the upstream services by status code.",
+		}, []string{"service", "method", "code"}),
+	}
+	m.registry.MustRegister(
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		prometheus.NewGoCollector(),
+		m.operationDuration,
+		m.operationErrors,
+		m.fieldDuration,
+		m.fieldErrors,
+		m.grpcDuration,
+		m.grpcRequests,
+	)
+	return m
+}
+
+// Handler serves the metrics in the prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
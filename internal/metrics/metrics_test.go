@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scrape fetches the metrics the way prometheus would
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != 200 {
+		t.Fatalf("scrape status = %d, want 200", rec.Code)
+	}
+	body, err := ioutil.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("reading scrape: %v", err)
+	}
+	return string(body)
+}
+
+func respond(ext graphqlExtension, name string, errs gqlerror.List) {
+	ctx := graphql.WithOperationContext(context.Background(), &graphql.OperationContext{
+		Operation: &ast.OperationDefinition{Name: name, Operation: ast.Query},
+	})
+	ext.InterceptResponse(ctx, func(ctx context.Context) *graphql.Response {
+		return &graphql.Response{Errors: errs}
+	})
+}
+
+func TestScrapeGraphQLOperations(t *testing.T) {
+	m := New()
+	ext := m.GraphQL([]string{"Feed"}).(graphqlExtension)
+
+	respond(ext, "Feed", nil)
+	respond(ext, "Feed", gqlerror.List{gqlerror.Errorf("boom")})
+	respond(ext, "RandomClientName", nil)
+	ext.InterceptResponse(context.Background(), func(ctx context.Context) *graphql.Response {
+		return nil
+	})
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`gateway_graphql_operation_duration_seconds_count{operation="Feed",type="query"} 2`,
+		`gateway_graphql_operation_errors_total{operation="Feed",type="query"} 1`,
+		`gateway_graphql_operation_duration_seconds_count{operation="other",type="query"} 1`,
+		`gateway_graphql_operation_duration_seconds_count{operation="unknown",type="unknown"} 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape is missing %s", want)
+		}
+	}
+	if strings.Contains(body, "RandomClientName") {
+		t.Error("scrape labels an operation name that is not in the manifest")
+	}
+}
+
+func TestScrapeGRPCCalls(t *testing.T) {
+	m := New()
+	interceptor := m.UnaryClientInterceptor()
+	invoke := func(err error) {
+		interceptor(context.Background(), "/users.UsersService/GetUser", nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return err
+			})
+	}
+	invoke(nil)
+	invoke(status.Error(codes.NotFound, "no user"))
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`gateway_grpc_client_handled_total{code="OK",method="GetUser",service="users.UsersService"} 1`,
+		`gateway_grpc_client_handled_total{code="NotFound",method="GetUser",service="users.UsersService"} 1`,
+		`gateway_grpc_client_handling_seconds_count{method="GetUser",service="users.UsersService"} 2`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape is missing %s", want)
+		}
+	}
+}
+
+func TestSplitMethod(t *testing.T) {
+	tests := []struct {
+		fullMethod  string
+		wantService string
+		wantMethod  string
+	}{
+		{fullMethod: "/users.UsersService/GetUser", wantService: "users.UsersService", wantMethod: "GetUser"},
+		{fullMethod: "GetUser", wantService: "unknown", wantMethod: "GetUser"},
+	}
+	for _, tt := range tests {
+		service, method := splitMethod(tt.fullMethod)
+		if service != tt.wantService || method != tt.wantMethod {
+			t.Errorf("splitMethod(%q) = %q, %q, want %q, %q", tt.fullMethod, service, method, tt.wantService, tt.wantMethod)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIP gets the ip of the client that made the request. Behind a trusted proxy
+// it is the address the proxy appended to X-Forwarded-For, as anything before it
+// was sent by the client and could be made up.
+func ClientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			hops := strings.Split(forwarded, ",")
+			return strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// GetHTTP gets the response writer and request injected into the context, if any
+func GetHTTP(ctx context.Context) (HTTP, bool) {
+	httpContext, ok := ctx.Value(HTTPKey).(HTTP)
+	return httpContext, ok
+}
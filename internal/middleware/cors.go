@@ -2,15 +2,40 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/rs/cors"
 )
 
+// allowedOrigins are the origins browsers may call the gateway from, each may hold one * wildcard
+var allowedOrigins = []string{"http://localhost:*"}
+
 // InjectCors if the middleware handler for CORS
 func InjectCors() func(http.Handler) http.Handler {
 	return cors.New(cors.Options{
-		AllowedOrigins:   []string{"http://localhost:*"},
+		AllowedOrigins:   allowedOrigins,
+		AllowedHeaders:   []string{"Origin", "Accept", "Content-Type", "X-Requested-With", RequestIDHeader, "X-CSRF-Token", "Authorization"},
+		ExposedHeaders:   []string{RequestIDHeader, "Retry-After"},
 		AllowCredentials: true,
 		//Debug:            true,
 	}).Handler
 }
+
+// AllowedOrigin reports whether a browser may call the gateway from the origin,
+// for the requests such as websocket upgrades that CORS does not cover
+func AllowedOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range allowedOrigins {
+		if i := strings.Index(allowed, "*"); i >= 0 {
+			prefix, suffix := allowed[:i], allowed[i+1:]
+			if len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+				return true
+			}
+			continue
+		}
+		if origin == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// RequestIDKey is the key used to extract the request id
+	RequestIDKey CtxKey = "requestID"
+	// RequestIDHeader is the header a request id is accepted from and echoed in
+	RequestIDHeader = "X-Request-ID"
+
+	// maxRequestIDLength bounds ids accepted from callers so they cannot flood the logs
+	maxRequestIDLength = 128
+)
+
+// InjectRequestID gives every request an id so that everything logged while
+// handling it, here and in the upstream services, can be tied together. An id
+// sent by the caller is kept when it is valid, otherwise a new one is generated.
+func InjectRequestID() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				generated, err := uuid.NewV4()
+				if err != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+				id = generated.String()
+			}
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+		})
+	}
+}
+
+// WithRequestID returns a context carrying the request id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, id)
+}
+
+// GetRequestID returns the id of the request being handled, or an empty string outside of a request
+func GetRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(RequestIDKey).(string)
+	return id
+}
+
+// validRequestID only accepts short ids of printable ascii so they are safe to log and forward
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
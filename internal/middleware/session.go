@@ -10,7 +10,7 @@ import (
 
 // InjectSession handles injecting the ResponseWriter and Request structs
 // into context so that resolver methods can use these to set and read cookies
-func InjectSession(session *sessions.CookieStore) func(http.Handler) http.Handler {
+func InjectSession(session sessions.Store) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			httpContext := HTTP{
@@ -45,7 +45,7 @@ type HTTP struct {
 
 // GetSession returns a cached session of the given name
 func GetSession(ctx context.Context, name string) *sessions.Session {
-	store := ctx.Value(SessionKey).(*sessions.CookieStore)
+	store := ctx.Value(SessionKey).(sessions.Store)
 	httpContext := ctx.Value(HTTPKey).(HTTP)
 
 	// Ignore err because a session is always returned even if one doesn't exist
@@ -62,3 +62,42 @@ func SaveSession(ctx context.Context, session *sessions.Session) error {
 	}
 	return nil
 }
+
+// DestroySession expires the session, revoking it when it is kept server side
+func DestroySession(ctx context.Context, session *sessions.Session) error {
+	for k := range session.Values {
+		delete(session.Values, k)
+	}
+	session.Options.MaxAge = -1
+	return SaveSession(ctx, session)
+}
+
+// regenerator is a session store that can give a session a new id
+type regenerator interface {
+	Regenerate(session *sessions.Session) error
+}
+
+// revoker is a session store that can revoke every session of a user
+type revoker interface {
+	RevokeUser(userUUID []byte) error
+}
+
+// RegenerateSession gives the session a new id when it is next saved, revoking the
+// old one. Stores that keep sessions in the cookie have no id to regenerate.
+func RegenerateSession(ctx context.Context, session *sessions.Session) error {
+	store, ok := ctx.Value(SessionKey).(regenerator)
+	if !ok {
+		return nil
+	}
+	return store.Regenerate(session)
+}
+
+// RevokeUserSessions revokes every session of the user. Stores that keep sessions in
+// the cookie cannot revoke them, so they last until they expire.
+func RevokeUserSessions(ctx context.Context, userUUID []byte) error {
+	store, ok := ctx.Value(SessionKey).(revoker)
+	if !ok {
+		return nil
+	}
+	return store.RevokeUser(userUUID)
+}
@@ -0,0 +1,288 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/gorilla/securecookie"
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/logging"
+	"github.com/srcabl/gateway/internal/session"
+	"go.uber.org/zap"
+)
+
+const (
+	// stateCookie holds the state of a sign in between its start and callback
+	stateCookie = "oidc_state"
+	// stateCookiePath limits the state cookie to the sign in endpoints
+	stateCookiePath = "/auth/"
+	// exchangeTimeout caps each call to a provider
+	exchangeTimeout = 10 * time.Second
+)
+
+var (
+	// ErrIdentityLinked is returned by a Linker when the identity is already linked to another user
+	ErrIdentityLinked = errors.New("identity is linked to another user")
+	// ErrEmailTaken is returned by a Linker when the identity's email belongs to a user it
+	// may not sign in as, who has to sign in some other way and link the identity
+	ErrEmailTaken = errors.New("email belongs to another user")
+)
+
+// Linker signs the user in with an identity, linking it to the logged in user, or to
+// a user it finds or creates when nobody is logged in
+type Linker interface {
+	SignInWithIdentity(context.Context, Identity) error
+}
+
+// flowState is what the state cookie holds for the callback to check
+type flowState struct {
+	Provider string
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// Handler handles signing in with OpenID Connect providers using the authorization
+// code flow with PKCE
+type Handler struct {
+	logger      *logging.Logger
+	providers   map[string]*provider
+	linker      Linker
+	codecs      []securecookie.Codec
+	secure      bool
+	stateTTL    time.Duration
+	redirectURL string
+}
+
+// NewHandler news up the sign in handler for the configured providers
+func NewHandler(cfg *config.Gateway, logger *logging.Logger, linker Linker) (*Handler, error) {
+	if cfg.OIDC.StateTTL <= 0 {
+		return nil, errors.New("oidc requires a positive state ttl")
+	}
+	if _, err := url.Parse(cfg.OIDC.RedirectURL); err != nil {
+		return nil, errors.Wrap(err, "oidc redirect url is not valid")
+	}
+	codecs, err := session.NewCodecs(cfg.Session, int(cfg.OIDC.StateTTL.Seconds()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up state cookie codecs")
+	}
+	client := &http.Client{Timeout: exchangeTimeout}
+	providers := make(map[string]*provider, len(cfg.OIDC.Providers))
+	for name, providerCfg := range cfg.OIDC.Providers {
+		p, err := newProvider(name, providerCfg, client)
+		if err != nil {
+			return nil, err
+		}
+		providers[name] = p
+	}
+	return &Handler{
+		logger:      logger.Named("oidc"),
+		providers:   providers,
+		linker:      linker,
+		codecs:      codecs,
+		secure:      cfg.Session.Secure,
+		stateTTL:    cfg.OIDC.StateTTL,
+		redirectURL: cfg.OIDC.RedirectURL,
+	}, nil
+}
+
+// Routes mounts the sign in endpoints of every provider
+func (h *Handler) Routes(router chi.Router) {
+	router.Get("/auth/{provider}/start", h.Start)
+	router.Get("/auth/{provider}/callback", h.Callback)
+}
+
+// Start handles sending the user to the provider to sign in
+func (h *Handler) Start(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.providers[chi.URLParam(r, "provider")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	flow := flowState{Provider: p.name}
+	for _, v := range []*string{&flow.State, &flow.Nonce, &flow.Verifier} {
+		random, err := randomString()
+		if err != nil {
+			h.fail(w, r, p, "server_error", err)
+			return
+		}
+		*v = random
+	}
+	authorizeURL, err := p.authorizeURL(r.Context(), flow)
+	if err != nil {
+		h.fail(w, r, p, "temporarily_unavailable", err)
+		return
+	}
+	encoded, err := securecookie.EncodeMulti(stateCookie, flow, h.codecs...)
+	if err != nil {
+		h.fail(w, r, p, "server_error", errors.Wrap(err, "failed to encode state cookie"))
+		return
+	}
+	http.SetCookie(w, h.stateCookie(encoded, int(h.stateTTL.Seconds())))
+	http.Redirect(w, r, authorizeURL, http.StatusFound)
+}
+
+// Callback handles the provider sending the user back, signing them in when the
+// provider's id token checks out
+func (h *Handler) Callback(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.providers[chi.URLParam(r, "provider")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	// the state is good for one callback only
+	http.SetCookie(w, h.stateCookie("", -1))
+	var flow flowState
+	cookie, err := r.Cookie(stateCookie)
+	if err == nil {
+		err = securecookie.DecodeMulti(stateCookie, cookie.Value, &flow, h.codecs...)
+	}
+	query := r.URL.Query()
+	if err != nil || flow.Provider != p.name || subtle.ConstantTimeCompare([]byte(flow.State), []byte(query.Get("state"))) != 1 {
+		h.fail(w, r, p, "invalid_state", errors.New("state does not match the sign in that was started"))
+		return
+	}
+	if providerErr := query.Get("error"); providerErr != "" {
+		h.fail(w, r, p, "access_denied", errors.Errorf("provider returned %s: %s", providerErr, query.Get("error_description")))
+		return
+	}
+	code := query.Get("code")
+	if code == "" {
+		h.fail(w, r, p, "invalid_request", errors.New("provider returned no code"))
+		return
+	}
+	idToken, err := p.exchange(r.Context(), code, flow.Verifier)
+	if err != nil {
+		h.fail(w, r, p, "temporarily_unavailable", err)
+		return
+	}
+	identity, err := p.verify(r.Context(), idToken, flow.Nonce)
+	if err != nil {
+		h.fail(w, r, p, "invalid_token", err)
+		return
+	}
+	if err := h.linker.SignInWithIdentity(r.Context(), *identity); err != nil {
+		switch errors.Cause(err) {
+		case ErrIdentityLinked:
+			h.fail(w, r, p, "identity_linked", err)
+			return
+		case ErrEmailTaken:
+			h.fail(w, r, p, "email_taken", err)
+			return
+		}
+		h.fail(w, r, p, "server_error", err)
+		return
+	}
+	h.logger.Ctx(r.Context()).Info("signed in", zap.String("provider", p.name))
+	http.Redirect(w, r, h.redirectURL, http.StatusFound)
+}
+
+// fail sends the user back to the app with an error code, logging what went wrong
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, p *provider, code string, err error) {
+	h.logger.Ctx(r.Context()).Warn("failed to sign in", zap.String("provider", p.name), zap.String("code", code), zap.Error(err))
+	redirect, _ := url.Parse(h.redirectURL)
+	query := redirect.Query()
+	query.Set("error", code)
+	redirect.RawQuery = query.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// stateCookie builds the state cookie. It is lax so that it is sent on the provider's
+// redirect back, which is a top level navigation from another site.
+func (h *Handler) stateCookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     stateCookie,
+		Value:    value,
+		Path:     stateCookiePath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   h.secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// authorizeURL builds the url that sends the user to the provider to sign in
+func (p *provider) authorizeURL(ctx context.Context, flow flowState) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	authorize, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.Wrap(err, "provider authorization endpoint is not valid")
+	}
+	challenge := sha256.Sum256([]byte(flow.Verifier))
+	query := authorize.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.CallbackURL)
+	query.Set("scope", strings.Join(p.scopes(), " "))
+	query.Set("state", flow.State)
+	query.Set("nonce", flow.Nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authorize.RawQuery = query.Encode()
+	return authorize.String(), nil
+}
+
+// exchange trades the code for the provider's id token
+func (p *provider) exchange(ctx context.Context, code, verifier string) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.CallbackURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to build token request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+	var res struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := p.doJSON(req, &res); err != nil {
+		return "", errors.Wrap(err, "failed to exchange code")
+	}
+	if res.IDToken == "" {
+		return "", errors.New("provider returned no id token")
+	}
+	return res.IDToken, nil
+}
+
+// scopes are the configured scopes, always including openid
+func (p *provider) scopes() []string {
+	for _, s := range p.cfg.Scopes {
+		if s == "openid" {
+			return p.cfg.Scopes
+		}
+	}
+	return append([]string{"openid"}, p.cfg.Scopes...)
+}
+
+// randomString generates a random url safe string, long enough for a PKCE verifier
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate random string")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,225 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/logging"
+)
+
+const testRedirectURL = "https://app.example/signed-in"
+
+// linker records who signed in, failing with err when set
+type linker struct {
+	identities []Identity
+	err        error
+}
+
+func (l *linker) SignInWithIdentity(ctx context.Context, identity Identity) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.identities = append(l.identities, identity)
+	return nil
+}
+
+func newRouter(t *testing.T, iss *issuer, l Linker) chi.Router {
+	t.Helper()
+	h, err := NewHandler(&config.Gateway{
+		Session: config.Session{Keys: []config.SessionKey{{Auth: "session auth key"}}},
+		OIDC: config.OIDC{
+			RedirectURL: testRedirectURL,
+			StateTTL:    10 * time.Minute,
+			Providers: map[string]config.OIDCProvider{
+				"test": {
+					Issuer:       iss.URL,
+					ClientID:     testClientID,
+					ClientSecret: testClientSecret,
+					CallbackURL:  testCallbackURL,
+					LinkByEmail:  true,
+				},
+			},
+		},
+	}, logging.NewNop(), l)
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+	router := chi.NewRouter()
+	h.Routes(router)
+	return router
+}
+
+// start starts a sign in, returning the state cookie and what was sent to the issuer
+func start(t *testing.T, router http.Handler) (*http.Cookie, url.Values) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/auth/test/start", nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("start status = %d, want %d", rec.Code, http.StatusFound)
+	}
+	location, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("start redirected to a bad url: %v", err)
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != stateCookie {
+		t.Fatalf("start set cookies %+v, want the state cookie", cookies)
+	}
+	return cookies[0], location.Query()
+}
+
+// callback comes back from the issuer, returning the error code the app is sent, if any
+func callback(t *testing.T, router http.Handler, cookie *http.Cookie, query url.Values) string {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/auth/test/callback?"+query.Encode(), nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("callback status = %d, want %d", rec.Code, http.StatusFound)
+	}
+	location, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil || !strings.HasPrefix(location.String(), testRedirectURL) {
+		t.Fatalf("callback redirected to %s, want the app", rec.Header().Get("Location"))
+	}
+	cleared := rec.Result().Cookies()
+	if len(cleared) != 1 || cleared[0].Name != stateCookie || cleared[0].MaxAge >= 0 {
+		t.Errorf("callback set cookies %+v, want the state cookie cleared", cleared)
+	}
+	return location.Query().Get("error")
+}
+
+// approved is the query the issuer sends back once the user approves the sign in
+func approved(iss *issuer, sent url.Values) url.Values {
+	return url.Values{
+		"state": {sent.Get("state")},
+		"code":  {iss.approve(sent.Get("code_challenge"), sent.Get("nonce"))},
+	}
+}
+
+func TestStart(t *testing.T) {
+	iss := newIssuer(t)
+	router := newRouter(t, iss, &linker{})
+	cookie, sent := start(t, router)
+
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             testClientID,
+		"redirect_uri":          testCallbackURL,
+		"scope":                 "openid",
+		"code_challenge_method": "S256",
+	}
+	for k, v := range want {
+		if got := sent.Get(k); got != v {
+			t.Errorf("authorize %s = %q, want %q", k, got, v)
+		}
+	}
+	for _, k := range []string{"state", "nonce", "code_challenge"} {
+		if sent.Get(k) == "" {
+			t.Errorf("authorize is missing %s", k)
+		}
+	}
+	if !cookie.HttpOnly || cookie.Path != stateCookiePath || cookie.SameSite != http.SameSiteLaxMode {
+		t.Errorf("state cookie = %+v, want http only, lax and on %s", cookie, stateCookiePath)
+	}
+
+	_, again := start(t, router)
+	if again.Get("state") == sent.Get("state") || again.Get("nonce") == sent.Get("nonce") {
+		t.Error("two sign ins share a state or nonce")
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/auth/other/start", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("start of an unknown provider status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestCallbackSignsIn(t *testing.T) {
+	iss := newIssuer(t)
+	l := &linker{}
+	router := newRouter(t, iss, l)
+	cookie, sent := start(t, router)
+
+	if code := callback(t, router, cookie, approved(iss, sent)); code != "" {
+		t.Fatalf("callback failed with %s", code)
+	}
+	want := Identity{
+		Provider:          "test",
+		Subject:           "subject",
+		Email:             "alice@example.com",
+		EmailVerified:     true,
+		PreferredUsername: "alice",
+		LinkByEmail:       true,
+	}
+	if len(l.identities) != 1 || l.identities[0] != want {
+		t.Errorf("signed in as %+v, want %+v", l.identities, want)
+	}
+}
+
+func TestCallbackFails(t *testing.T) {
+	tests := []struct {
+		name      string
+		noCookie  bool
+		query     func(iss *issuer, sent url.Values) url.Values
+		tweak     func(claims map[string]interface{})
+		linkErr   error
+		wantError string
+	}{
+		{name: "no state cookie", noCookie: true, query: approved, wantError: "invalid_state"},
+		{name: "other state", query: func(iss *issuer, sent url.Values) url.Values {
+			q := approved(iss, sent)
+			q.Set("state", "other")
+			return q
+		}, wantError: "invalid_state"},
+		{name: "access denied", query: func(iss *issuer, sent url.Values) url.Values {
+			return url.Values{"state": {sent.Get("state")}, "error": {"access_denied"}}
+		}, wantError: "access_denied"},
+		{name: "no code", query: func(iss *issuer, sent url.Values) url.Values {
+			return url.Values{"state": {sent.Get("state")}}
+		}, wantError: "invalid_request"},
+		{name: "code not issued", query: func(iss *issuer, sent url.Values) url.Values {
+			return url.Values{"state": {sent.Get("state")}, "code": {"made up"}}
+		}, wantError: "temporarily_unavailable"},
+		{name: "code for another verifier", query: func(iss *issuer, sent url.Values) url.Values {
+			return url.Values{"state": {sent.Get("state")}, "code": {iss.approve("other challenge", sent.Get("nonce"))}}
+		}, wantError: "temporarily_unavailable"},
+		{name: "id token for another sign in", query: func(iss *issuer, sent url.Values) url.Values {
+			return url.Values{"state": {sent.Get("state")}, "code": {iss.approve(sent.Get("code_challenge"), "other nonce")}}
+		}, wantError: "invalid_token"},
+		{name: "id token expired", query: approved, tweak: func(claims map[string]interface{}) {
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		}, wantError: "invalid_token"},
+		{name: "identity linked", query: approved, linkErr: errors.Wrap(ErrIdentityLinked, "linking"), wantError: "identity_linked"},
+		{name: "email taken", query: approved, linkErr: ErrEmailTaken, wantError: "email_taken"},
+		{name: "linker fails", query: approved, linkErr: errors.New("users is down"), wantError: "server_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iss := newIssuer(t)
+			iss.tweak = tt.tweak
+			l := &linker{err: tt.linkErr}
+			router := newRouter(t, iss, l)
+			cookie, sent := start(t, router)
+			if tt.noCookie {
+				cookie = nil
+			}
+			if code := callback(t, router, cookie, tt.query(iss, sent)); code != tt.wantError {
+				t.Errorf("callback error = %q, want %q", code, tt.wantError)
+			}
+			if len(l.identities) != 0 {
+				t.Errorf("signed in as %+v, want nobody", l.identities)
+			}
+		})
+	}
+}
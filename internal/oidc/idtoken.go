@@ -0,0 +1,163 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// clockSkew is how far the clocks of the gateway and a provider may disagree
+const clockSkew = time.Minute
+
+// Identity is who a provider says signed in
+type Identity struct {
+	Provider          string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+	// LinkByEmail is set when the provider is trusted to link to the user with the same verified email
+	LinkByEmail bool
+}
+
+// claims are the id token claims the gateway reads
+type claims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          audience `json:"aud"`
+	AuthorizedParty   string   `json:"azp"`
+	Expiry            int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     bool     `json:"email_verified"`
+	PreferredUsername string   `json:"preferred_username"`
+}
+
+// audience is one audience or a list of them
+type audience []string
+
+// UnmarshalJSON reads an audience given as a string or a list of strings
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*a = audience{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return errors.New("aud is neither a string nor a list of strings")
+	}
+	*a = many
+	return nil
+}
+
+func (a audience) contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// verify checks the id token was signed by the provider for this client and this
+// sign in, returning who signed in
+func (p *provider) verify(ctx context.Context, idToken, nonce string) (*Identity, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("id token is not a signed jwt")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errors.Wrap(err, "failed to decode id token header")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("failed to decode id token signature")
+	}
+	key, err := p.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := verifySignature(header.Alg, key, digest[:], sig); err != nil {
+		return nil, err
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, errors.Wrap(err, "failed to decode id token claims")
+	}
+	now := p.now()
+	switch {
+	case c.Issuer != p.cfg.Issuer:
+		return nil, errors.Errorf("id token was issued by %s", c.Issuer)
+	case !c.Audience.contains(p.cfg.ClientID):
+		return nil, errors.New("id token was not issued for this client")
+	case len(c.Audience) > 1 && c.AuthorizedParty != p.cfg.ClientID:
+		return nil, errors.New("id token was not authorized for this client")
+	case now.After(time.Unix(c.Expiry, 0).Add(clockSkew)):
+		return nil, errors.New("id token has expired")
+	case time.Unix(c.IssuedAt, 0).After(now.Add(clockSkew)):
+		return nil, errors.New("id token was issued in the future")
+	case subtle.ConstantTimeCompare([]byte(c.Nonce), []byte(nonce)) != 1:
+		return nil, errors.New("id token was not issued for this sign in")
+	case c.Subject == "":
+		return nil, errors.New("id token has no subject")
+	}
+	return &Identity{
+		Provider:          p.name,
+		Subject:           c.Subject,
+		Email:             c.Email,
+		EmailVerified:     c.EmailVerified,
+		PreferredUsername: c.PreferredUsername,
+		LinkByEmail:       p.cfg.LinkByEmail,
+	}, nil
+}
+
+// verifySignature checks the signature of the digest. Only the algorithms of the key's
+// type are accepted, so a token cannot pick a weaker one, such as none or HS256.
+func verifySignature(alg string, key crypto.PublicKey, digest, sig []byte) error {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if alg != "RS256" {
+			return errors.Errorf("id token is signed with %s, not RS256", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, sig); err != nil {
+			return errors.New("id token signature is not valid")
+		}
+	case *ecdsa.PublicKey:
+		if alg != "ES256" || len(sig) != 64 {
+			return errors.Errorf("id token is signed with %s, not ES256", alg)
+		}
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return errors.New("id token signature is not valid")
+		}
+	default:
+		return errors.New("id token is signed with an unsupported key")
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode segment")
+	}
+	return json.Unmarshal(b, v)
+}
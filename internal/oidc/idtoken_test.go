@@ -0,0 +1,116 @@
+package oidc
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/srcabl/gateway/internal/config"
+)
+
+func TestVerify(t *testing.T) {
+	iss := newIssuer(t)
+	p, err := newProvider("test", config.OIDCProvider{
+		Issuer:      iss.URL,
+		ClientID:    testClientID,
+		CallbackURL: testCallbackURL,
+	}, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("newProvider() error = %v", err)
+	}
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	signed := func(alg, kid string, tweak func(map[string]interface{})) string {
+		claims := iss.claims("nonce")
+		if tweak != nil {
+			tweak(claims)
+		}
+		return iss.sign(alg, kid, claims)
+	}
+	tests := []struct {
+		name    string
+		idToken string
+		wantErr string
+	}{
+		{name: "rsa", idToken: signed("RS256", "rsa", nil)},
+		{name: "ec", idToken: signed("ES256", "ec", nil)},
+		{name: "audience list with azp", idToken: signed("RS256", "rsa", func(c map[string]interface{}) {
+			c["aud"] = []string{testClientID, "other"}
+			c["azp"] = testClientID
+		})},
+		{name: "within clock skew", idToken: signed("RS256", "rsa", func(c map[string]interface{}) {
+			c["exp"] = now.Add(-clockSkew / 2).Unix()
+		})},
+		{name: "not a jwt", idToken: "abc.def", wantErr: "not a signed jwt"},
+		{name: "tampered claims", idToken: func() string {
+			parts := strings.Split(signed("RS256", "rsa", nil), ".")
+			parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"mallory"}`))
+			return strings.Join(parts, ".")
+		}(), wantErr: "signature is not valid"},
+		{name: "alg none", idToken: func() string {
+			parts := strings.Split(signed("RS256", "rsa", nil), ".")
+			parts[0] = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"rsa"}`))
+			return parts[0] + "." + parts[1] + "."
+		}(), wantErr: "not RS256"},
+		{name: "rsa key with es256", idToken: signed("ES256", "rsa", nil), wantErr: "not RS256"},
+		{name: "unknown key", idToken: signed("RS256", "other", nil), wantErr: "no signing key"},
+		{name: "encryption key", idToken: signed("RS256", "enc", nil), wantErr: "no signing key"},
+		{name: "other issuer", idToken: signed("RS256", "rsa", func(c map[string]interface{}) {
+			c["iss"] = "https://evil.example"
+		}), wantErr: "issued by"},
+		{name: "other audience", idToken: signed("RS256", "rsa", func(c map[string]interface{}) {
+			c["aud"] = "other"
+		}), wantErr: "not issued for this client"},
+		{name: "audience list without azp", idToken: signed("RS256", "rsa", func(c map[string]interface{}) {
+			c["aud"] = []string{testClientID, "other"}
+		}), wantErr: "not authorized for this client"},
+		{name: "expired", idToken: signed("RS256", "rsa", func(c map[string]interface{}) {
+			c["exp"] = now.Add(-2 * clockSkew).Unix()
+		}), wantErr: "expired"},
+		{name: "issued in the future", idToken: signed("RS256", "rsa", func(c map[string]interface{}) {
+			c["iat"] = now.Add(2 * clockSkew).Unix()
+		}), wantErr: "in the future"},
+		{name: "other nonce", idToken: signed("RS256", "rsa", func(c map[string]interface{}) {
+			c["nonce"] = "other"
+		}), wantErr: "not issued for this sign in"},
+		{name: "no subject", idToken: signed("RS256", "rsa", func(c map[string]interface{}) {
+			delete(c, "sub")
+		}), wantErr: "no subject"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := p.verify(context.Background(), tt.idToken, "nonce")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("verify() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verify() error = %v", err)
+			}
+			if identity.Subject != "subject" || identity.Provider != "test" {
+				t.Errorf("verify() = %+v, want the subject of the test provider", identity)
+			}
+		})
+	}
+}
+
+func TestMetadataRejectsOtherIssuer(t *testing.T) {
+	iss := newIssuer(t)
+	p, err := newProvider("test", config.OIDCProvider{
+		Issuer:      iss.URL + "/",
+		ClientID:    testClientID,
+		CallbackURL: testCallbackURL,
+	}, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("newProvider() error = %v", err)
+	}
+	if _, err := p.metadata(context.Background()); err == nil || !strings.Contains(err.Error(), "not "+iss.URL+"/") {
+		t.Errorf("metadata() error = %v, want the issuer mismatch", err)
+	}
+}
@@ -0,0 +1,166 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID     = "gateway"
+	testClientSecret = "client secret"
+	testCallbackURL  = "https://gateway.example/auth/test/callback"
+)
+
+var (
+	keysOnce sync.Once
+	rsaKey   *rsa.PrivateKey
+	ecKey    *ecdsa.PrivateKey
+)
+
+// testKeys generates the issuer's signing keys once for every test
+func testKeys(t *testing.T) (*rsa.PrivateKey, *ecdsa.PrivateKey) {
+	t.Helper()
+	keysOnce.Do(func() {
+		var err error
+		if rsaKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			t.Fatalf("failed to generate rsa key: %v", err)
+		}
+		if ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			t.Fatalf("failed to generate ec key: %v", err)
+		}
+	})
+	return rsaKey, ecKey
+}
+
+// grant is what the issuer remembers about a code it handed out
+type grant struct {
+	challenge string
+	nonce     string
+}
+
+// issuer stands in for an OpenID Connect provider, serving discovery, its signing keys
+// and a token endpoint that checks the PKCE verifier and client secret
+type issuer struct {
+	*httptest.Server
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+
+	mu     sync.Mutex
+	grants map[string]grant
+	// tweak changes the claims of the id tokens the token endpoint issues
+	tweak func(claims map[string]interface{})
+}
+
+func newIssuer(t *testing.T) *issuer {
+	t.Helper()
+	rsaKey, ecKey := testKeys(t)
+	iss := &issuer{rsaKey: rsaKey, ecKey: ecKey, grants: map[string]grant{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(metadata{
+			Issuer:                iss.URL,
+			AuthorizationEndpoint: iss.URL + "/authorize",
+			TokenEndpoint:         iss.URL + "/token",
+			JWKSURI:               iss.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jwks{Keys: []jwk{
+			{Kid: "rsa", Kty: "RSA", Use: "sig", N: encodeBigInt(rsaKey.N), E: encodeBigInt(big.NewInt(int64(rsaKey.E)))},
+			{Kid: "ec", Kty: "EC", Crv: "P-256", X: encodeBigInt(ecKey.X), Y: encodeBigInt(ecKey.Y)},
+			{Kid: "enc", Kty: "RSA", Use: "enc", N: encodeBigInt(rsaKey.N), E: encodeBigInt(big.NewInt(int64(rsaKey.E)))},
+		}})
+	})
+	mux.HandleFunc("/token", iss.token)
+	iss.Server = httptest.NewServer(mux)
+	t.Cleanup(iss.Close)
+	return iss
+}
+
+// approve signs the user in at the issuer, handing out a code for the sign in
+func (iss *issuer) approve(challenge, nonce string) string {
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	code := encodeBigInt(big.NewInt(int64(len(iss.grants) + 1)))
+	iss.grants[code] = grant{challenge: challenge, nonce: nonce}
+	return code
+}
+
+// token exchanges a code for an id token, once
+func (iss *issuer) token(w http.ResponseWriter, r *http.Request) {
+	// client credentials are form encoded before going into basic auth
+	id, secret, ok := r.BasicAuth()
+	id, _ = url.QueryUnescape(id)
+	secret, _ = url.QueryUnescape(secret)
+	if r.Method != http.MethodPost || !ok || id != testClientID || secret != testClientSecret {
+		http.Error(w, "invalid_client", http.StatusUnauthorized)
+		return
+	}
+	iss.mu.Lock()
+	g, ok := iss.grants[r.PostFormValue("code")]
+	delete(iss.grants, r.PostFormValue("code"))
+	iss.mu.Unlock()
+	verifier := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !ok || r.PostFormValue("grant_type") != "authorization_code" || r.PostFormValue("redirect_uri") != testCallbackURL ||
+		base64.RawURLEncoding.EncodeToString(verifier[:]) != g.challenge {
+		http.Error(w, "invalid_grant", http.StatusBadRequest)
+		return
+	}
+	claims := iss.claims(g.nonce)
+	if iss.tweak != nil {
+		iss.tweak(claims)
+	}
+	json.NewEncoder(w).Encode(map[string]string{"id_token": iss.sign("RS256", "rsa", claims)})
+}
+
+// claims are the claims of a good id token for the sign in
+func (iss *issuer) claims(nonce string) map[string]interface{} {
+	now := time.Now()
+	return map[string]interface{}{
+		"iss":                iss.URL,
+		"sub":                "subject",
+		"aud":                testClientID,
+		"exp":                now.Add(time.Hour).Unix(),
+		"iat":                now.Unix(),
+		"nonce":              nonce,
+		"email":              "alice@example.com",
+		"email_verified":     true,
+		"preferred_username": "alice",
+	}
+}
+
+// sign signs the claims into a jwt with the issuer's key of the algorithm
+func (iss *issuer) sign(alg, kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signing := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signing))
+	var sig []byte
+	switch alg {
+	case "RS256":
+		sig, _ = rsa.SignPKCS1v15(rand.Reader, iss.rsaKey, crypto.SHA256, digest[:])
+	case "ES256":
+		r, s, _ := ecdsa.Sign(rand.Reader, iss.ecKey, digest[:])
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+	return signing + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
@@ -0,0 +1,211 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+)
+
+const (
+	// discoveryPath is where an issuer publishes its metadata
+	discoveryPath = "/.well-known/openid-configuration"
+	// keyRefreshInterval is how often the keys may be refetched for a key id that is not known yet
+	keyRefreshInterval = time.Minute
+	// maxResponseSize caps how much of a provider response is read
+	maxResponseSize = 1 << 20
+)
+
+// metadata is the part of an issuer's discovery document the gateway uses
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// provider is an OpenID Connect provider. Its metadata is discovered on first use, so
+// a provider being down does not stop the gateway starting, and its signing keys are
+// refetched when an id token is signed by a key it does not know yet. The lock only
+// guards the fields, it is never held while calling the provider.
+type provider struct {
+	name   string
+	cfg    config.OIDCProvider
+	client *http.Client
+	now    func() time.Time
+
+	mu          sync.Mutex
+	meta        *metadata
+	keys        map[string]crypto.PublicKey
+	keysFetched time.Time
+}
+
+func newProvider(name string, cfg config.OIDCProvider, client *http.Client) (*provider, error) {
+	if cfg.Issuer == "" || cfg.ClientID == "" || cfg.CallbackURL == "" {
+		return nil, errors.Errorf("oidc provider %s requires an issuer, client id and callback url", name)
+	}
+	return &provider{
+		name:   name,
+		cfg:    cfg,
+		client: client,
+		now:    time.Now,
+	}, nil
+}
+
+// metadata gets the provider's metadata, discovering it the first time
+func (p *provider) metadata(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	known := p.meta
+	p.mu.Unlock()
+	if known != nil {
+		return known, nil
+	}
+	// concurrent first uses may each discover, which is cheaper than queueing them behind one call
+	var meta metadata
+	if err := p.getJSON(ctx, strings.TrimSuffix(p.cfg.Issuer, "/")+discoveryPath, &meta); err != nil {
+		return nil, errors.Wrap(err, "failed to discover provider")
+	}
+	// the issuer must be exactly the one configured, or its id tokens could be from anyone
+	if meta.Issuer != p.cfg.Issuer {
+		return nil, errors.Errorf("provider says its issuer is %s, not %s", meta.Issuer, p.cfg.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("provider metadata is missing an endpoint")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta == nil {
+		p.meta = &meta
+	}
+	return p.meta, nil
+}
+
+// key gets the signing key with the id, refetching the provider's keys if it is not known
+func (p *provider) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	if key, ok := p.keys[kid]; ok {
+		p.mu.Unlock()
+		return key, nil
+	}
+	if p.now().Sub(p.keysFetched) < keyRefreshInterval {
+		p.mu.Unlock()
+		return nil, errors.Errorf("no signing key %s", kid)
+	}
+	// claim the refetch before making it, so only one caller refetches each interval
+	lastFetched := p.keysFetched
+	p.keysFetched = p.now()
+	p.mu.Unlock()
+
+	var set jwks
+	if err := p.getJSON(ctx, meta.JWKSURI, &set); err != nil {
+		// a failed refetch does not count, so the next caller tries again
+		p.mu.Lock()
+		p.keysFetched = lastFetched
+		p.mu.Unlock()
+		return nil, errors.Wrap(err, "failed to fetch signing keys")
+	}
+	keys := set.publicKeys()
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+	key, ok := keys[kid]
+	if !ok {
+		return nil, errors.Errorf("no signing key %s", kid)
+	}
+	return key, nil
+}
+
+func (p *provider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to build request")
+	}
+	req.Header.Set("Accept", "application/json")
+	return p.doJSON(req, v)
+}
+
+func (p *provider) doJSON(req *http.Request, v interface{}) error {
+	res, err := p.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", req.URL.Host)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("%s responded %s", req.URL.Host, res.Status)
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(v); err != nil {
+		return errors.Wrapf(err, "failed to decode response from %s", req.URL.Host)
+	}
+	return nil
+}
+
+// jwks is a JSON web key set
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// jwk is a JSON web key, only RSA and P-256 signing keys are used
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKeys gets the signing keys of the set by their id, skipping any it cannot use
+func (s jwks) publicKeys() map[string]crypto.PublicKey {
+	keys := make(map[string]crypto.PublicKey, len(s.Keys))
+	for _, k := range s.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		switch k.Kty {
+		case "RSA":
+			n, nErr := decodeBigInt(k.N)
+			e, eErr := decodeBigInt(k.E)
+			if nErr != nil || eErr != nil || !e.IsInt64() {
+				continue
+			}
+			keys[k.Kid] = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case "EC":
+			if k.Crv != "P-256" {
+				continue
+			}
+			x, xErr := decodeBigInt(k.X)
+			y, yErr := decodeBigInt(k.Y)
+			if xErr != nil || yErr != nil || !elliptic.P256().IsOnCurve(x, y) {
+				continue
+			}
+			keys[k.Kid] = &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		}
+	}
+	return keys
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("not a base64url encoded integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,84 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// cursorVersion prefixes every cursor so the encoding can change without
+	// misreading cursors handed out before
+	cursorVersion = "v1"
+	// seenLength is how many bytes of a link's uuid it is remembered by in a cursor
+	seenLength = 8
+)
+
+// Key is the upstream sort key of a post. Posts are ordered newest first by
+// when they were created, with the uuid breaking ties so the order is stable.
+type Key struct {
+	CreatedAt int64
+	UUID      []byte
+	// Seen are the links already shown by the time a merged listing reached the
+	// key, by SeenLink, so paging on from it does not show them again
+	Seen []string
+}
+
+// SeenLink gets the short id a link is remembered by in a cursor's seen links
+func SeenLink(linkUUID []byte) string {
+	if len(linkUUID) > seenLength {
+		linkUUID = linkUUID[:seenLength]
+	}
+	return hex.EncodeToString(linkUUID)
+}
+
+// Before reports whether k comes before other in the post order
+func (k Key) Before(other Key) bool {
+	if k.CreatedAt != other.CreatedAt {
+		return k.CreatedAt > other.CreatedAt
+	}
+	return bytes.Compare(k.UUID, other.UUID) > 0
+}
+
+// Cursor encodes the key as an opaque cursor
+func (k Key) Cursor() string {
+	parts := []string{cursorVersion, strconv.FormatInt(k.CreatedAt, 10), hex.EncodeToString(k.UUID)}
+	if len(k.Seen) > 0 {
+		parts = append(parts, strings.Join(k.Seen, "."))
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(strings.Join(parts, ":")))
+}
+
+// ParseCursor decodes a cursor made by Key.Cursor
+func ParseCursor(cursor string) (*Key, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode cursor")
+	}
+	parts := strings.Split(string(raw), ":")
+	if (len(parts) != 3 && len(parts) != 4) || parts[0] != cursorVersion {
+		return nil, errors.New("cursor is not in a known format")
+	}
+	createdAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse cursor time")
+	}
+	uuid, err := hex.DecodeString(parts[2])
+	if err != nil || len(uuid) != 16 {
+		return nil, errors.New("cursor does not hold a valid id")
+	}
+	key := &Key{CreatedAt: createdAt, UUID: uuid}
+	if len(parts) == 4 {
+		for _, seen := range strings.Split(parts[3], ".") {
+			if b, err := hex.DecodeString(seen); err != nil || len(b) != seenLength {
+				return nil, errors.New("cursor does not hold valid seen links")
+			}
+			key.Seen = append(key.Seen, seen)
+		}
+	}
+	return key, nil
+}
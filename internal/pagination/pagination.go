@@ -0,0 +1,115 @@
+package pagination
+
+import (
+	"fmt"
+
+	"github.com/srcabl/gateway/internal/config"
+)
+
+// Args are the relay connection arguments of a paginated query
+type Args struct {
+	First  *int
+	After  *string
+	Last   *int
+	Before *string
+}
+
+// Page is a window of the post order, as asked for by connection arguments
+type Page struct {
+	// Limit is the most items in the page
+	Limit int
+	// After and Before bound the page, exclusive of the keys themselves
+	After  *Key
+	Before *Key
+	// FromEnd takes the page from the end of the bounded range, for last
+	FromEnd bool
+}
+
+// ArgumentError describes connection arguments that cannot be paginated
+type ArgumentError struct {
+	Field   string
+	Message string
+}
+
+func (e *ArgumentError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Pager turns connection arguments into pages
+type Pager struct {
+	defaultSize int
+	maxSize     int
+}
+
+// NewPager news up a pager with the configured page sizes
+func NewPager(cfg config.Pagination) *Pager {
+	return &Pager{
+		defaultSize: cfg.DefaultPageSize,
+		maxSize:     cfg.MaxPageSize,
+	}
+}
+
+// Page validates the connection arguments and works out the page they ask for
+func (p *Pager) Page(args Args) (Page, *ArgumentError) {
+	if args.First != nil && args.Last != nil {
+		return Page{}, &ArgumentError{Field: "first", Message: "first and last cannot be used together"}
+	}
+	page := Page{Limit: p.defaultSize}
+	if args.First != nil {
+		if err := p.checkSize("first", *args.First); err != nil {
+			return Page{}, err
+		}
+		page.Limit = *args.First
+	}
+	if args.Last != nil {
+		if err := p.checkSize("last", *args.Last); err != nil {
+			return Page{}, err
+		}
+		page.Limit = *args.Last
+		page.FromEnd = true
+	}
+	if args.After != nil {
+		key, err := ParseCursor(*args.After)
+		if err != nil {
+			return Page{}, &ArgumentError{Field: "after", Message: "after is not a valid cursor"}
+		}
+		page.After = key
+	}
+	if args.Before != nil {
+		key, err := ParseCursor(*args.Before)
+		if err != nil {
+			return Page{}, &ArgumentError{Field: "before", Message: "before is not a valid cursor"}
+		}
+		page.Before = key
+	}
+	return page, nil
+}
+
+func (p *Pager) checkSize(field string, size int) *ArgumentError {
+	// a limit of zero is never worth asking the upstreams for
+	if size < 1 {
+		return &ArgumentError{Field: field, Message: fmt.Sprintf("%s must be at least 1", field)}
+	}
+	if size > p.maxSize {
+		return &ArgumentError{Field: field, Message: fmt.Sprintf("%s cannot be more than %d", field, p.maxSize)}
+	}
+	return nil
+}
+
+// HasNextPage reports whether there are items after the page, given whether
+// the upstream had more items in the direction the page was taken
+func (p Page) HasNextPage(hasMore bool) bool {
+	if p.FromEnd {
+		return p.Before != nil
+	}
+	return hasMore
+}
+
+// HasPreviousPage reports whether there are items before the page, given
+// whether the upstream had more items in the direction the page was taken
+func (p Page) HasPreviousPage(hasMore bool) bool {
+	if p.FromEnd {
+		return hasMore
+	}
+	return p.After != nil
+}
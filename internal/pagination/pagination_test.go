@@ -0,0 +1,163 @@
+package pagination
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/srcabl/gateway/internal/config"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func testKey(createdAt int64) Key {
+	return Key{CreatedAt: createdAt, UUID: bytes.Repeat([]byte{byte(createdAt)}, 16)}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		key  Key
+	}{
+		{name: "plain", key: testKey(1600000000)},
+		{name: "negative time", key: Key{CreatedAt: -5, UUID: make([]byte, 16)}},
+		{name: "with seen links", key: Key{CreatedAt: 42, UUID: make([]byte, 16), Seen: []string{
+			SeenLink(bytes.Repeat([]byte{1}, 16)),
+			SeenLink(bytes.Repeat([]byte{2}, 16)),
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCursor(tt.key.Cursor())
+			if err != nil {
+				t.Fatalf("ParseCursor() error = %v", err)
+			}
+			if got.CreatedAt != tt.key.CreatedAt || !bytes.Equal(got.UUID, tt.key.UUID) {
+				t.Errorf("ParseCursor() = %+v, want %+v", got, tt.key)
+			}
+			if len(got.Seen) != len(tt.key.Seen) {
+				t.Fatalf("ParseCursor() seen = %v, want %v", got.Seen, tt.key.Seen)
+			}
+			for i := range got.Seen {
+				if got.Seen[i] != tt.key.Seen[i] {
+					t.Errorf("ParseCursor() seen = %v, want %v", got.Seen, tt.key.Seen)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCursorRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "!!!"},
+		{name: "unknown version", cursor: "djI6MTowMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMA"},
+		{name: "empty", cursor: ""},
+		{name: "short uuid", cursor: Key{CreatedAt: 1, UUID: []byte{1, 2}}.Cursor()},
+		{name: "bad seen link", cursor: Key{CreatedAt: 1, UUID: make([]byte, 16), Seen: []string{"zz"}}.Cursor()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCursor(tt.cursor); err == nil {
+				t.Errorf("ParseCursor(%q) succeeded, want an error", tt.cursor)
+			}
+		})
+	}
+}
+
+func TestKeyBefore(t *testing.T) {
+	newer, older := testKey(2), testKey(1)
+	if !newer.Before(older) {
+		t.Error("newer key should come before older key")
+	}
+	if older.Before(newer) {
+		t.Error("older key should not come before newer key")
+	}
+	tie := Key{CreatedAt: 2, UUID: make([]byte, 16)}
+	if !newer.Before(tie) || tie.Before(newer) {
+		t.Error("keys created at the same time should be ordered by uuid")
+	}
+}
+
+func TestPagerPage(t *testing.T) {
+	pager := NewPager(config.Pagination{DefaultPageSize: 20, MaxPageSize: 50})
+	cursor := testKey(7).Cursor()
+	tests := []struct {
+		name       string
+		args       Args
+		wantLimit  int
+		wantEnd    bool
+		wantAfter  bool
+		wantBefore bool
+		wantField  string
+	}{
+		{name: "defaults", args: Args{}, wantLimit: 20},
+		{name: "first", args: Args{First: intPtr(5)}, wantLimit: 5},
+		{name: "first at max", args: Args{First: intPtr(50)}, wantLimit: 50},
+		{name: "first zero", args: Args{First: intPtr(0)}, wantField: "first"},
+		{name: "first negative", args: Args{First: intPtr(-1)}, wantField: "first"},
+		{name: "first over max", args: Args{First: intPtr(51)}, wantField: "first"},
+		{name: "last", args: Args{Last: intPtr(3)}, wantLimit: 3, wantEnd: true},
+		{name: "last zero", args: Args{Last: intPtr(0)}, wantField: "last"},
+		{name: "first and last", args: Args{First: intPtr(1), Last: intPtr(1)}, wantField: "first"},
+		{name: "after", args: Args{First: intPtr(2), After: strPtr(cursor)}, wantLimit: 2, wantAfter: true},
+		{name: "before", args: Args{Last: intPtr(2), Before: strPtr(cursor)}, wantLimit: 2, wantEnd: true, wantBefore: true},
+		{name: "after and before", args: Args{After: strPtr(cursor), Before: strPtr(cursor)}, wantLimit: 20, wantAfter: true, wantBefore: true},
+		{name: "bad after", args: Args{After: strPtr("nope")}, wantField: "after"},
+		{name: "bad before", args: Args{Before: strPtr("nope")}, wantField: "before"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, argErr := pager.Page(tt.args)
+			if tt.wantField != "" {
+				if argErr == nil || argErr.Field != tt.wantField {
+					t.Fatalf("Page() error = %v, want an error on %s", argErr, tt.wantField)
+				}
+				return
+			}
+			if argErr != nil {
+				t.Fatalf("Page() error = %v", argErr)
+			}
+			if page.Limit != tt.wantLimit || page.FromEnd != tt.wantEnd {
+				t.Errorf("Page() = %+v, want limit %d from end %v", page, tt.wantLimit, tt.wantEnd)
+			}
+			if (page.After != nil) != tt.wantAfter || (page.Before != nil) != tt.wantBefore {
+				t.Errorf("Page() after = %v before = %v, want after %v before %v", page.After, page.Before, tt.wantAfter, tt.wantBefore)
+			}
+		})
+	}
+}
+
+func TestPageInfo(t *testing.T) {
+	key := testKey(1)
+	tests := []struct {
+		name         string
+		page         Page
+		hasMore      bool
+		wantNext     bool
+		wantPrevious bool
+	}{
+		{name: "first page with more", page: Page{Limit: 2}, hasMore: true, wantNext: true},
+		{name: "first page without more", page: Page{Limit: 2}},
+		{name: "after with more", page: Page{Limit: 2, After: &key}, hasMore: true, wantNext: true, wantPrevious: true},
+		{name: "last page with more", page: Page{Limit: 2, FromEnd: true}, hasMore: true, wantPrevious: true},
+		{name: "last before without more", page: Page{Limit: 2, FromEnd: true, Before: &key}, wantNext: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.page.HasNextPage(tt.hasMore); got != tt.wantNext {
+				t.Errorf("HasNextPage() = %v, want %v", got, tt.wantNext)
+			}
+			if got := tt.page.HasPreviousPage(tt.hasMore); got != tt.wantPrevious {
+				t.Errorf("HasPreviousPage() = %v, want %v", got, tt.wantPrevious)
+			}
+		})
+	}
+}
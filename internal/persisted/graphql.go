@@ -0,0 +1,88 @@
+package persisted
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// NewCache news up the cache registered queries are kept in
+func NewCache(cfg config.PersistedQueries) (graphql.Cache, error) {
+	switch cfg.Cache {
+	case "memory":
+		return lru.New(cfg.CacheSize), nil
+	default:
+		return nil, errors.Errorf("unknown persisted query cache %s", cfg.Cache)
+	}
+}
+
+// GraphQL returns the gqlgen extensions for automatic persisted queries. In persisted
+// only mode queries are looked up in the manifest alone, and any query not in it is rejected.
+func GraphQL(cfg config.PersistedQueries) ([]graphql.HandlerExtension, error) {
+	if !cfg.PersistedOnly {
+		cache, err := NewCache(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return []graphql.HandlerExtension{extension.AutomaticPersistedQuery{Cache: cache}}, nil
+	}
+	if cfg.Manifest == "" {
+		return nil, errors.New("persisted only mode requires a manifest")
+	}
+	manifest, err := LoadManifest(cfg.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	return []graphql.HandlerExtension{
+		extension.AutomaticPersistedQuery{Cache: manifest},
+		allowlist{manifest: manifest},
+	}, nil
+}
+
+// Operations lists the operation names in the configured manifest, if there is one
+func Operations(cfg config.PersistedQueries) ([]string, error) {
+	if cfg.Manifest == "" {
+		return nil, nil
+	}
+	manifest, err := LoadManifest(cfg.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	return manifest.Operations(), nil
+}
+
+// allowlist rejects queries that are not in the manifest. It must come after
+// automatic persisted queries so queries sent by hash have been looked up.
+type allowlist struct {
+	manifest *Manifest
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationParameterMutator
+} = allowlist{}
+
+// ExtensionName names the extension
+func (a allowlist) ExtensionName() string {
+	return "PersistedOnly"
+}
+
+// Validate has nothing to validate against the schema
+func (a allowlist) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// MutateOperationParameters rejects the query if it is not in the manifest
+func (a allowlist) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	if rawParams.Query == "" || a.manifest.Allowed(rawParams.Query) {
+		return nil
+	}
+	err := gqlerror.Errorf("query is not persisted")
+	err.Extensions = map[string]interface{}{"code": "PERSISTED_QUERY_NOT_ALLOWED"}
+	return err
+}
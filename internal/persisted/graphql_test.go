@@ -0,0 +1,150 @@
+package persisted
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/srcabl/gateway/internal/config"
+)
+
+// mutate runs the params through the extensions in order, as the handler does
+func mutate(t *testing.T, extensions []graphql.HandlerExtension, params *graphql.RawParams) (string, string) {
+	t.Helper()
+	ctx := graphql.WithOperationContext(context.Background(), &graphql.OperationContext{})
+	for _, ext := range extensions {
+		mutator, ok := ext.(graphql.OperationParameterMutator)
+		if !ok {
+			continue
+		}
+		if err := mutator.MutateOperationParameters(ctx, params); err != nil {
+			code, _ := err.Extensions["code"].(string)
+			return code, err.Message
+		}
+	}
+	return "", ""
+}
+
+func persistedQuery(hash string) map[string]interface{} {
+	return map[string]interface{}{
+		"persistedQuery": map[string]interface{}{"version": 1, "sha256Hash": hash},
+	}
+}
+
+func TestPersistedOnly(t *testing.T) {
+	cfg := config.PersistedQueries{
+		PersistedOnly: true,
+		Manifest:      writeManifest(t, `{"`+computeHash(postQuery)+`": "`+postQuery+`"}`),
+	}
+	extensions, err := GraphQL(cfg)
+	if err != nil {
+		t.Fatalf("GraphQL() error = %v", err)
+	}
+	tests := []struct {
+		name   string
+		params graphql.RawParams
+		query  string
+		code   string
+	}{
+		{
+			name:   "by hash",
+			params: graphql.RawParams{Extensions: persistedQuery(computeHash(postQuery))},
+			query:  postQuery,
+		},
+		{
+			name:   "persisted query sent in full",
+			params: graphql.RawParams{Query: postQuery},
+			query:  postQuery,
+		},
+		{
+			name:   "persisted query sent with its hash",
+			params: graphql.RawParams{Query: postQuery, Extensions: persistedQuery(computeHash(postQuery))},
+			query:  postQuery,
+		},
+		{
+			name:   "ad hoc query",
+			params: graphql.RawParams{Query: usersQuery},
+			code:   "PERSISTED_QUERY_NOT_ALLOWED",
+		},
+		{
+			name:   "ad hoc query sent with its hash",
+			params: graphql.RawParams{Query: usersQuery, Extensions: persistedQuery(computeHash(usersQuery))},
+			code:   "PERSISTED_QUERY_NOT_ALLOWED",
+		},
+		{
+			name:   "unknown hash",
+			params: graphql.RawParams{Extensions: persistedQuery(computeHash(usersQuery))},
+			code:   "PERSISTED_QUERY_NOT_FOUND",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			code, msg := mutate(t, extensions, &params)
+			if code != tt.code {
+				t.Fatalf("code = %q (%s), want %q", code, msg, tt.code)
+			}
+			if tt.code == "" && params.Query != tt.query {
+				t.Errorf("query = %q, want %q", params.Query, tt.query)
+			}
+		})
+	}
+
+	// an ad hoc query sent with its hash must not be added to the manifest
+	params := graphql.RawParams{Extensions: persistedQuery(computeHash(usersQuery))}
+	if code, _ := mutate(t, extensions, &params); code != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Errorf("code after sending an ad hoc query = %q, want PERSISTED_QUERY_NOT_FOUND", code)
+	}
+}
+
+func TestPersistedHashMismatch(t *testing.T) {
+	cfg := config.PersistedQueries{
+		PersistedOnly: true,
+		Manifest:      writeManifest(t, `{"`+computeHash(postQuery)+`": "`+postQuery+`"}`),
+	}
+	extensions, err := GraphQL(cfg)
+	if err != nil {
+		t.Fatalf("GraphQL() error = %v", err)
+	}
+	params := graphql.RawParams{Query: usersQuery, Extensions: persistedQuery(computeHash(postQuery))}
+	if _, msg := mutate(t, extensions, &params); msg == "" {
+		t.Error("a query sent with another query's hash was accepted")
+	}
+}
+
+func TestAutomaticPersistedQueries(t *testing.T) {
+	extensions, err := GraphQL(config.PersistedQueries{Cache: "memory", CacheSize: 10})
+	if err != nil {
+		t.Fatalf("GraphQL() error = %v", err)
+	}
+	params := graphql.RawParams{Extensions: persistedQuery(computeHash(usersQuery))}
+	if code, _ := mutate(t, extensions, &params); code != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Fatalf("code before registering = %q, want PERSISTED_QUERY_NOT_FOUND", code)
+	}
+	params = graphql.RawParams{Query: usersQuery, Extensions: persistedQuery(computeHash(usersQuery))}
+	if code, msg := mutate(t, extensions, &params); code != "" {
+		t.Fatalf("registering a query failed with %q (%s)", code, msg)
+	}
+	params = graphql.RawParams{Extensions: persistedQuery(computeHash(usersQuery))}
+	if code, _ := mutate(t, extensions, &params); code != "" || params.Query != usersQuery {
+		t.Errorf("lookup after registering = %q, query %q, want the query", code, params.Query)
+	}
+}
+
+func TestGraphQLConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.PersistedQueries
+	}{
+		{name: "unknown cache", cfg: config.PersistedQueries{Cache: "redis"}},
+		{name: "persisted only without a manifest", cfg: config.PersistedQueries{PersistedOnly: true}},
+		{name: "missing manifest", cfg: config.PersistedQueries{PersistedOnly: true, Manifest: "missing.json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GraphQL(tt.cfg); err == nil {
+				t.Error("GraphQL() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package persisted
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// Manifest is the allowlist of queries generated by the client build, keyed by
+// the sha256 hash of each query. It reads as a cache so automatic persisted
+// queries can look queries up by hash, but nothing can be added to it.
+type Manifest struct {
+	queries    map[string]string
+	operations []string
+}
+
+// apolloManifest is the apollo-persisted-query-manifest format
+type apolloManifest struct {
+	Operations []struct {
+		ID   string `json:"id"`
+		Body string `json:"body"`
+	} `json:"operations"`
+}
+
+// LoadManifest reads the manifest at the path. It is either an object of hashes
+// to queries or an apollo persisted query manifest.
+func LoadManifest(path string) (*Manifest, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read manifest %s", path)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal manifest %s", path)
+	}
+	queries := map[string]string{}
+	if _, ok := fields["operations"]; ok {
+		var apollo apolloManifest
+		if err := json.Unmarshal(raw, &apollo); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal apollo manifest %s", path)
+		}
+		for _, op := range apollo.Operations {
+			queries[op.ID] = op.Body
+		}
+	} else if err := json.Unmarshal(raw, &queries); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal manifest %s", path)
+	}
+	// the hashes must be those clients send, or lookups by hash would never match
+	for hash, query := range queries {
+		if computeHash(query) != hash {
+			return nil, errors.Errorf("query %s in manifest %s is not keyed by its sha256 hash", hash, path)
+		}
+	}
+	operations, err := operationNames(queries)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest %s", path)
+	}
+	return &Manifest{queries: queries, operations: operations}, nil
+}
+
+// Operations lists the names of the operations in the manifest
+func (m *Manifest) Operations() []string {
+	return m.operations
+}
+
+// Allowed reports whether the query is in the manifest
+func (m *Manifest) Allowed(query string) bool {
+	_, ok := m.queries[computeHash(query)]
+	return ok
+}
+
+// Get gets the query with the hash
+func (m *Manifest) Get(ctx context.Context, hash string) (interface{}, bool) {
+	query, ok := m.queries[hash]
+	return query, ok
+}
+
+// Add does nothing, only the client build adds to the manifest
+func (m *Manifest) Add(ctx context.Context, hash string, value interface{}) {}
+
+func operationNames(queries map[string]string) ([]string, error) {
+	seen := map[string]bool{}
+	var names []string
+	for hash, query := range queries {
+		doc, parseErr := parser.ParseQuery(&ast.Source{Input: query})
+		if parseErr != nil {
+			return nil, errors.Wrapf(parseErr, "failed to parse query %s", hash)
+		}
+		for _, op := range doc.Operations {
+			if op.Name != "" && !seen[op.Name] {
+				seen[op.Name] = true
+				names = append(names, op.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+func computeHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,123 @@
+package persisted
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const (
+	postQuery  = "query Post { post { title } }"
+	usersQuery = "query Users { users { name } } query Viewer { viewer { name } }"
+)
+
+// writeManifest writes the manifest contents to a file and gets its path
+func writeManifest(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func TestLoadManifest(t *testing.T) {
+	tests := []struct {
+		name       string
+		contents   string
+		operations []string
+		err        string
+	}{
+		{
+			name:       "hashes to queries",
+			contents:   `{"` + computeHash(postQuery) + `": "` + postQuery + `", "` + computeHash(usersQuery) + `": "` + usersQuery + `"}`,
+			operations: []string{"Post", "Users", "Viewer"},
+		},
+		{
+			name:       "apollo",
+			contents:   `{"format": "apollo-persisted-query-manifest", "version": 1, "operations": [{"id": "` + computeHash(postQuery) + `", "name": "Post", "type": "query", "body": "` + postQuery + `"}]}`,
+			operations: []string{"Post"},
+		},
+		{
+			name:     "empty",
+			contents: `{}`,
+		},
+		{
+			name:     "hash mismatch",
+			contents: `{"` + computeHash(usersQuery) + `": "` + postQuery + `"}`,
+			err:      "is not keyed by its sha256 hash",
+		},
+		{
+			name:     "apollo hash mismatch",
+			contents: `{"operations": [{"id": "abc", "body": "` + postQuery + `"}]}`,
+			err:      "is not keyed by its sha256 hash",
+		},
+		{
+			name:     "unparseable query",
+			contents: `{"` + computeHash("query {") + `": "query {"}`,
+			err:      "failed to parse manifest",
+		},
+		{
+			name:     "not json",
+			contents: `queries`,
+			err:      "failed to unmarshal manifest",
+		},
+		{
+			name:     "not queries",
+			contents: `{"hash": 1}`,
+			err:      "failed to unmarshal manifest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := LoadManifest(writeManifest(t, tt.contents))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("LoadManifest() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadManifest() error = %v", err)
+			}
+			operations := m.Operations()
+			sort.Strings(operations)
+			if strings.Join(operations, ",") != strings.Join(tt.operations, ",") {
+				t.Errorf("Operations() = %v, want %v", operations, tt.operations)
+			}
+		})
+	}
+}
+
+func TestLoadManifestMissing(t *testing.T) {
+	if _, err := LoadManifest(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadManifest() of a missing file succeeded, want an error")
+	}
+}
+
+func TestManifestLookup(t *testing.T) {
+	m, err := LoadManifest(writeManifest(t, `{"`+computeHash(postQuery)+`": "`+postQuery+`"}`))
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	ctx := context.Background()
+	if query, ok := m.Get(ctx, computeHash(postQuery)); !ok || query != postQuery {
+		t.Errorf("Get() = %v, %v, want the post query", query, ok)
+	}
+	if _, ok := m.Get(ctx, computeHash(usersQuery)); ok {
+		t.Error("Get() of a hash not in the manifest found a query")
+	}
+	m.Add(ctx, computeHash(usersQuery), usersQuery)
+	if _, ok := m.Get(ctx, computeHash(usersQuery)); ok {
+		t.Error("Add() added a query to the manifest")
+	}
+	if !m.Allowed(postQuery) {
+		t.Error("Allowed() of a query in the manifest = false")
+	}
+	if m.Allowed(usersQuery) || m.Allowed(postQuery+" ") {
+		t.Error("Allowed() of a query not in the manifest = true")
+	}
+}
@@ -0,0 +1,122 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/srcabl/gateway/graph/model"
+)
+
+// PostEventKind is what happened to a post
+type PostEventKind int
+
+const (
+	// PostCreated is published when a post is created
+	PostCreated PostEventKind = iota
+	// PostUpdated is published when a post is updated
+	PostUpdated
+	// PostDeleted is published when a post is deleted
+	PostDeleted
+)
+
+// PostEvent is something that happened to a post
+type PostEvent struct {
+	Kind PostEventKind
+	Post *model.PartialPost
+	// SourceIDs are the ids of the sources at the head of the post's link
+	SourceIDs []string
+}
+
+// Bus fans post events out to subscribers
+type Bus interface {
+	// Publish sends the event to every subscriber without waiting on any of them
+	Publish(PostEvent)
+	// Subscribe gets the events published until the context is done. A subscriber
+	// that falls more than the buffer behind has its channel closed, rather than
+	// holding up publishers or silently missing events.
+	Subscribe(context.Context) <-chan PostEvent
+	// Close closes every subscriber's channel, and those of any that subscribe after
+	Close() error
+	// Closed reports whether the bus has been closed, telling a subscriber whose
+	// channel was closed at shutdown from one that fell behind
+	Closed() bool
+}
+
+type memoryBus struct {
+	mu          sync.Mutex
+	buffer      int
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+type subscriber struct {
+	events chan PostEvent
+}
+
+// NewMemoryBus news up an in memory bus, buffering up to buffer events per subscriber
+func NewMemoryBus(buffer int) Bus {
+	return &memoryBus{
+		buffer:      buffer,
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+// Publish sends the event to every subscriber, dropping those that cannot keep up
+func (b *memoryBus) Publish(event PostEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subscribers {
+		select {
+		case s.events <- event:
+		default:
+			b.remove(s)
+		}
+	}
+}
+
+// Subscribe adds a subscriber until the context is done
+func (b *memoryBus) Subscribe(ctx context.Context) <-chan PostEvent {
+	s := &subscriber{events: make(chan PostEvent, b.buffer)}
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(s.events)
+		return s.events
+	}
+	b.subscribers[s] = struct{}{}
+	b.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(s)
+	}()
+	return s.events
+}
+
+// Close removes every subscriber
+func (b *memoryBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for s := range b.subscribers {
+		b.remove(s)
+	}
+	return nil
+}
+
+// Closed reports whether the bus has been closed
+func (b *memoryBus) Closed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+// remove closes the subscriber's channel if it has not been already, the lock must be held
+func (b *memoryBus) remove(s *subscriber) {
+	if _, ok := b.subscribers[s]; !ok {
+		return
+	}
+	delete(b.subscribers, s)
+	close(s.events)
+}
@@ -0,0 +1,115 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/srcabl/gateway/graph/model"
+)
+
+func event(id string) PostEvent {
+	return PostEvent{Kind: PostCreated, Post: &model.PartialPost{ID: id}}
+}
+
+// receive waits for the next event, failing if the channel is closed or nothing arrives
+func receive(t *testing.T, events <-chan PostEvent) PostEvent {
+	t.Helper()
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("channel closed, want an event")
+		}
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return PostEvent{}
+}
+
+// waitClosed waits for the channel to be closed, draining anything left in it
+func waitClosed(t *testing.T, events <-chan PostEvent) {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for the channel to close")
+		}
+	}
+}
+
+func TestBusPublishesToEverySubscriber(t *testing.T) {
+	bus := NewMemoryBus(4)
+	defer bus.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first, second := bus.Subscribe(ctx), bus.Subscribe(ctx)
+
+	bus.Publish(event("1"))
+	bus.Publish(event("2"))
+	for _, events := range []<-chan PostEvent{first, second} {
+		for _, want := range []string{"1", "2"} {
+			if got := receive(t, events); got.Post.ID != want {
+				t.Errorf("received post %s, want %s", got.Post.ID, want)
+			}
+		}
+	}
+}
+
+func TestBusUnsubscribesWhenContextDone(t *testing.T) {
+	bus := NewMemoryBus(4)
+	defer bus.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	events := bus.Subscribe(ctx)
+	cancel()
+	waitClosed(t, events)
+	// publishing after the subscriber has gone must not panic on the closed channel
+	bus.Publish(event("1"))
+}
+
+func TestBusDropsSlowSubscribers(t *testing.T) {
+	bus := NewMemoryBus(1)
+	defer bus.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	slow, fast := bus.Subscribe(ctx), bus.Subscribe(ctx)
+
+	bus.Publish(event("1"))
+	if got := receive(t, fast); got.Post.ID != "1" {
+		t.Fatalf("received post %s, want 1", got.Post.ID)
+	}
+	// slow has not read the first event, so its buffer is full and it is dropped
+	bus.Publish(event("2"))
+	if got := receive(t, fast); got.Post.ID != "2" {
+		t.Fatalf("received post %s, want 2", got.Post.ID)
+	}
+	if got := receive(t, slow); got.Post.ID != "1" {
+		t.Errorf("received post %s, want the buffered 1", got.Post.ID)
+	}
+	waitClosed(t, slow)
+	if bus.Closed() {
+		t.Error("Closed() = true after dropping a subscriber, want the bus still open")
+	}
+}
+
+func TestBusCloseClosesSubscribers(t *testing.T) {
+	bus := NewMemoryBus(1)
+	events := bus.Subscribe(context.Background())
+	if bus.Closed() {
+		t.Fatal("Closed() = true before Close()")
+	}
+	if err := bus.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	waitClosed(t, events)
+	if !bus.Closed() {
+		t.Error("Closed() = false after Close()")
+	}
+	// subscribing once closed must not wait on events that will never come
+	waitClosed(t, bus.Subscribe(context.Background()))
+}
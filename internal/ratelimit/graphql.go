@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// GraphQL returns the gqlgen extension limiting how often mutations and queries are called
+func (l *Limiter) GraphQL() graphql.HandlerExtension {
+	return graphqlExtension{l: l}
+}
+
+type graphqlExtension struct {
+	l *Limiter
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.FieldInterceptor
+} = graphqlExtension{}
+
+// ExtensionName names the extension
+func (e graphqlExtension) ExtensionName() string {
+	return "RateLimit"
+}
+
+// Validate has nothing to validate against the schema
+func (e graphqlExtension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptField checks the rate limit of each mutation and query field before resolving it
+func (e graphqlExtension) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || (fc.Object != "Mutation" && fc.Object != "Query") {
+		return next(ctx)
+	}
+	if err := e.l.Allow(ctx, fc.Field.Name); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
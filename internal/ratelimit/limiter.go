@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/middleware"
+	"github.com/srcabl/gateway/internal/util"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Limiter limits how often each client ip and each user may call an operation
+type Limiter struct {
+	store      Store
+	trustProxy bool
+	buckets    map[string]Bucket
+}
+
+// New news up a limiter with the configured policies, keeping its buckets in the store
+func New(cfg config.RateLimits, store Store) (*Limiter, error) {
+	buckets := make(map[string]Bucket, len(cfg.Policies))
+	for operation, policy := range cfg.Policies {
+		if policy.Limit <= 0 || policy.Period <= 0 {
+			return nil, errors.Errorf("rate limit policy for %s needs a positive limit and period", operation)
+		}
+		burst := policy.Burst
+		if burst == 0 {
+			burst = policy.Limit
+		}
+		buckets[operation] = Bucket{
+			Rate:  float64(policy.Limit) / policy.Period.Seconds(),
+			Burst: burst,
+		}
+	}
+	return &Limiter{
+		store:      store,
+		trustProxy: cfg.TrustProxy,
+		buckets:    buckets,
+	}, nil
+}
+
+// Allow takes a token for the operation from the buckets of the client ip and, when
+// logged in, of the user. It returns a RATE_LIMITED error if either is empty.
+func (l *Limiter) Allow(ctx context.Context, operation string) error {
+	bucket, ok := l.buckets[operation]
+	if !ok {
+		return nil
+	}
+	httpContext, ok := middleware.GetHTTP(ctx)
+	if !ok {
+		return nil
+	}
+	keys := []string{fmt.Sprintf("%s:ip:%s", operation, middleware.ClientIP(httpContext.R, l.trustProxy))}
+	if userUUID := util.GetUserUUIDFromContext(ctx); userUUID != nil {
+		keys = append(keys, fmt.Sprintf("%s:user:%s", operation, hex.EncodeToString(userUUID)))
+	}
+	for _, key := range keys {
+		allowed, wait, err := l.store.Take(key, bucket)
+		if err != nil {
+			return errors.Wrap(err, "failed to check rate limit")
+		}
+		if !allowed {
+			return rateLimited(httpContext, wait)
+		}
+	}
+	return nil
+}
+
+// rateLimited sets the Retry-After header and makes the error telling the client when to retry
+func rateLimited(httpContext middleware.HTTP, wait time.Duration) *gqlerror.Error {
+	retryAfter := int(math.Ceil(wait.Seconds()))
+	(*httpContext.W).Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	err := gqlerror.Errorf("too many requests, retry in %d seconds", retryAfter)
+	err.Extensions = map[string]interface{}{
+		"code":       "RATE_LIMITED",
+		"retryAfter": retryAfter,
+	}
+	return err
+}
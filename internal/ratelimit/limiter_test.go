@@ -0,0 +1,128 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/middleware"
+	"github.com/srcabl/gateway/internal/util"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// request makes the context of a request from the address, signed in as the user if any
+func request(remoteAddr string, userUUID []byte) (context.Context, *httptest.ResponseRecorder) {
+	r := httptest.NewRequest("POST", "/graphql", nil)
+	r.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	var w http.ResponseWriter = rec
+	ctx := context.WithValue(context.Background(), middleware.HTTPKey, middleware.HTTP{W: &w, R: r})
+	ctx = context.WithValue(ctx, middleware.SessionKey, sessions.Store(sessions.NewCookieStore([]byte("session key"))))
+	if userUUID != nil {
+		ctx = util.SetTokenUserUUIDToContext(ctx, userUUID)
+	}
+	return ctx, rec
+}
+
+func TestNewRejectsBadPolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy config.RatePolicy
+	}{
+		{name: "no limit", policy: config.RatePolicy{Period: time.Minute}},
+		{name: "no period", policy: config.RatePolicy{Limit: 1}},
+		{name: "negative limit", policy: config.RatePolicy{Limit: -1, Period: time.Minute}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.RateLimits{Policies: map[string]config.RatePolicy{"login": tt.policy}}
+			if _, err := New(cfg, NewMemoryStore()); err == nil {
+				t.Error("New() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestAllow(t *testing.T) {
+	alice, bob := bytes.Repeat([]byte{1}, 16), bytes.Repeat([]byte{2}, 16)
+	type call struct {
+		operation  string
+		remoteAddr string
+		user       []byte
+		wantLimit  bool
+	}
+	tests := []struct {
+		name  string
+		calls []call
+	}{
+		{name: "per ip", calls: []call{
+			{operation: "login", remoteAddr: "10.0.0.1:1000"},
+			{operation: "login", remoteAddr: "10.0.0.1:2000"},
+			{operation: "login", remoteAddr: "10.0.0.1:3000", wantLimit: true},
+			{operation: "login", remoteAddr: "10.0.0.2:1000"},
+		}},
+		{name: "per operation", calls: []call{
+			{operation: "login", remoteAddr: "10.0.0.1:1000"},
+			{operation: "login", remoteAddr: "10.0.0.1:1000"},
+			{operation: "register", remoteAddr: "10.0.0.1:1000"},
+		}},
+		{name: "unlimited operation", calls: []call{
+			{operation: "feed", remoteAddr: "10.0.0.1:1000"},
+			{operation: "feed", remoteAddr: "10.0.0.1:1000"},
+			{operation: "feed", remoteAddr: "10.0.0.1:1000"},
+		}},
+		{name: "per user across ips", calls: []call{
+			{operation: "login", remoteAddr: "10.0.0.1:1000", user: alice},
+			{operation: "login", remoteAddr: "10.0.0.2:1000", user: alice},
+			{operation: "login", remoteAddr: "10.0.0.3:1000", user: alice, wantLimit: true},
+			{operation: "login", remoteAddr: "10.0.0.4:1000", user: bob},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := New(config.RateLimits{Policies: map[string]config.RatePolicy{
+				"login":    {Limit: 2, Period: time.Minute},
+				"register": {Limit: 2, Period: time.Minute},
+			}}, NewMemoryStore())
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			for i, c := range tt.calls {
+				ctx, rec := request(c.remoteAddr, c.user)
+				err := l.Allow(ctx, c.operation)
+				if !c.wantLimit {
+					if err != nil {
+						t.Errorf("call %d: Allow() error = %v", i, err)
+					}
+					continue
+				}
+				gqlErr, ok := err.(*gqlerror.Error)
+				if !ok || gqlErr.Extensions["code"] != "RATE_LIMITED" {
+					t.Fatalf("call %d: Allow() error = %v, want RATE_LIMITED", i, err)
+				}
+				if gqlErr.Extensions["retryAfter"] != 30 || rec.Header().Get("Retry-After") != "30" {
+					t.Errorf("call %d: retry after %v, header %q, want 30", i, gqlErr.Extensions["retryAfter"], rec.Header().Get("Retry-After"))
+				}
+			}
+		})
+	}
+}
+
+func TestAllowWithoutHTTP(t *testing.T) {
+	l, err := New(config.RateLimits{Policies: map[string]config.RatePolicy{
+		"login": {Limit: 1, Period: time.Minute},
+	}}, NewMemoryStore())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := l.Allow(context.Background(), "login"); err != nil {
+			t.Errorf("Allow() outside of http error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Bucket describes a token bucket, refilled at Rate tokens a second up to Burst tokens
+type Bucket struct {
+	Rate  float64
+	Burst int
+}
+
+// Store keeps token buckets
+type Store interface {
+	// Take takes a token from the bucket with the key. When the bucket is empty it
+	// reports how long until a token will be available instead.
+	Take(key string, bucket Bucket) (bool, time.Duration, error)
+}
+
+type storedBucket struct {
+	Bucket
+	tokens float64
+	last   time.Time
+}
+
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*storedBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// sweepInterval is how often buckets that have refilled are cleared out
+const sweepInterval = time.Minute
+
+// NewMemoryStore news up an in memory bucket store
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets: map[string]*storedBucket{},
+		now:     time.Now,
+	}
+}
+
+// Take takes a token from the bucket, refilling it for the time since it was last used
+func (s *memoryStore) Take(key string, bucket Bucket) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		s.sweep(now)
+	}
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &storedBucket{Bucket: bucket, tokens: float64(bucket.Burst), last: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(bucket.Burst), b.tokens+now.Sub(b.last).Seconds()*bucket.Rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+	wait := time.Duration((1 - b.tokens) / bucket.Rate * float64(time.Second))
+	return false, wait, nil
+}
+
+// sweep clears out the buckets that have not been used for long enough to have
+// refilled, a full bucket is the same as no bucket at all
+func (s *memoryStore) sweep(now time.Time) {
+	s.lastSweep = now
+	for k, b := range s.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*b.Rate >= float64(b.Burst) {
+			delete(s.buckets, k)
+		}
+	}
+}
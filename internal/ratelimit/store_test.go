@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreTake(t *testing.T) {
+	bucket := Bucket{Rate: 1, Burst: 2}
+	type take struct {
+		after       time.Duration
+		key         string
+		wantAllowed bool
+		wantWait    time.Duration
+	}
+	tests := []struct {
+		name  string
+		takes []take
+	}{
+		{name: "burst then empty", takes: []take{
+			{key: "a", wantAllowed: true},
+			{key: "a", wantAllowed: true},
+			{key: "a", wantWait: time.Second},
+		}},
+		{name: "refills over time", takes: []take{
+			{key: "a", wantAllowed: true},
+			{key: "a", wantAllowed: true},
+			{after: 500 * time.Millisecond, key: "a", wantWait: 500 * time.Millisecond},
+			{after: 500 * time.Millisecond, key: "a", wantAllowed: true},
+			{key: "a", wantWait: time.Second},
+		}},
+		{name: "refills no more than the burst", takes: []take{
+			{key: "a", wantAllowed: true},
+			{after: time.Hour, key: "a", wantAllowed: true},
+			{key: "a", wantAllowed: true},
+			{key: "a", wantWait: time.Second},
+		}},
+		{name: "keys are separate", takes: []take{
+			{key: "a", wantAllowed: true},
+			{key: "a", wantAllowed: true},
+			{key: "b", wantAllowed: true},
+			{key: "a", wantWait: time.Second},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore().(*memoryStore)
+			now := time.Now()
+			store.now = func() time.Time { return now }
+			for i, take := range tt.takes {
+				now = now.Add(take.after)
+				allowed, wait, err := store.Take(take.key, bucket)
+				if err != nil {
+					t.Fatalf("take %d: Take() error = %v", i, err)
+				}
+				if allowed != take.wantAllowed || wait != take.wantWait {
+					t.Errorf("take %d: Take() = %v, %v, want %v, %v", i, allowed, wait, take.wantAllowed, take.wantWait)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	store := NewMemoryStore().(*memoryStore)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	fast, slow := Bucket{Rate: 1, Burst: 1}, Bucket{Rate: 1.0 / 3600, Burst: 1}
+	store.Take("fast", fast)
+	store.Take("slow", slow)
+
+	now = now.Add(sweepInterval)
+	store.Take("other", fast)
+	if _, ok := store.buckets["fast"]; ok {
+		t.Error("refilled bucket was not swept")
+	}
+	if _, ok := store.buckets["slow"]; !ok {
+		t.Error("bucket still refilling was swept")
+	}
+}
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/srcabl/gateway/internal/middleware"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// presentError adds the request id to the extensions of every error so users
+// can quote it when reporting a problem
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	if id := middleware.GetRequestID(ctx); id != "" {
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]interface{}{}
+		}
+		gqlErr.Extensions["requestId"] = id
+	}
+	return gqlErr
+}
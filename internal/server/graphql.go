@@ -1,71 +1,245 @@
 package server
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/go-chi/chi"
 	"github.com/gorilla/sessions"
+	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
 	"github.com/srcabl/gateway/graph"
 	"github.com/srcabl/gateway/graph/generated"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/csrf"
+	"github.com/srcabl/gateway/internal/dataloader"
+	"github.com/srcabl/gateway/internal/limits"
+	"github.com/srcabl/gateway/internal/logging"
+	"github.com/srcabl/gateway/internal/metrics"
 	"github.com/srcabl/gateway/internal/middleware"
+	"github.com/srcabl/gateway/internal/oidc"
+	"github.com/srcabl/gateway/internal/persisted"
+	"github.com/srcabl/gateway/internal/ratelimit"
 	"github.com/srcabl/gateway/internal/services"
-	"github.com/srcabl/services/pkg/config"
+	"github.com/srcabl/gateway/internal/session"
+	"github.com/srcabl/gateway/internal/token"
+	"github.com/srcabl/gateway/internal/tracing"
+	"go.uber.org/zap"
 )
 
 // GraphQL defines the behavior of the graphql server
 type GraphQL interface {
-	Run() (func() error, error)
+	Run() (func(context.Context) error, error)
+	Errors() <-chan error
 }
 
 // GraphQLServer is the graphql server
 type GraphQLServer struct {
-	address    string
-	port       int
-	sessionkey string
+	address      string
+	port         int
+	adminAddress string
+	adminPort    int
+	sessionStore sessions.Store
+	tokens       *token.Tokens
+	oidc         *oidc.Handler
 
-	server *handler.Server
+	logger        *logging.Logger
+	levelEndpoint bool
+	drainDelay    time.Duration
+
+	server      *handler.Server
+	metrics     *metrics.Metrics
+	httpServer  *http.Server
+	adminServer *http.Server
+	errs        chan error
+
+	upstreams []func(context.Context) services.Health
+	fetchers  dataloader.Fetchers
+	// ready is 1 while the server is accepting traffic
+	ready int32
 }
 
 // New news up a graphql server
-func New(cfg *config.Gateway, usersClient services.UsersClient, postsClient services.PostsClient, sourceClient services.SourcesClient) (GraphQL, error) {
+func New(cfg *config.Gateway, logger *logging.Logger, metrics *metrics.Metrics, tokens *token.Tokens, usersClient services.UsersClient, postsClient services.PostsClient, sourceClient services.SourcesClient) (GraphQL, error) {
 	resolver, err := graph.New(usersClient, postsClient, sourceClient)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to new the graphql resolver")
 	}
 	config := generated.Config{Resolvers: resolver}
 	schema := generated.NewExecutableSchema(config)
-	srv := handler.NewDefaultServer(schema)
+	srv := handler.New(schema)
+	srv.AddTransport(transport.Websocket{
+		Upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     checkWebsocketOrigin,
+		},
+		InitFunc:              authenticateWebsocket(tokens),
+		KeepAlivePingInterval: cfg.Subscriptions.KeepAlive,
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+	srv.SetQueryCache(lru.New(1000))
+	srv.Use(extension.Introspection{})
+	persistedQueries, err := persisted.GraphQL(cfg.PersistedQueries)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set up persisted queries")
+	}
+	for _, ext := range persistedQueries {
+		srv.Use(ext)
+	}
+	srv.Use(csrf.New(cfg.CSRF, cfg.Session.Secure).GraphQL())
+	srv.Use(limits.GraphQL(cfg.Limits, cfg.Pagination.DefaultPageSize))
+	limiter, err := ratelimit.New(cfg.RateLimits, ratelimit.NewMemoryStore())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up the rate limiter")
+	}
+	srv.Use(limiter.GraphQL())
+	srv.Use(tracing.GraphQL())
+	operations, err := persisted.Operations(cfg.PersistedQueries)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the persisted operations")
+	}
+	srv.Use(metrics.GraphQL(operations))
+	srv.SetErrorPresenter(presentError)
+	oidcHandler, err := oidc.NewHandler(cfg, logger, usersClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up oidc sign in")
+	}
+	sessionStore, err := session.NewStore(cfg.Session)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up the session store")
+	}
 
 	return &GraphQLServer{
-		address:    cfg.Server.Address,
-		port:       cfg.Server.Port,
-		sessionkey: cfg.Server.SessionKey,
-		server:     srv,
+		address:       cfg.Server.Address,
+		port:          cfg.Server.Port,
+		adminAddress:  cfg.Admin.Address,
+		adminPort:     cfg.Admin.Port,
+		sessionStore:  sessionStore,
+		tokens:        tokens,
+		oidc:          oidcHandler,
+		logger:        logger.Named("server"),
+		levelEndpoint: cfg.Logging.LevelEndpoint,
+		drainDelay:    cfg.Lifecycle.ShutdownDelay,
+		server:        srv,
+		metrics:       metrics,
+		errs:          make(chan error, 1),
+		fetchers: dataloader.Fetchers{
+			Users:   usersClient.GetUsersByID,
+			Links:   postsClient.GetLinksByID,
+			Sources: sourceClient.GetSourcesByID,
+		},
+		upstreams: []func(context.Context) services.Health{
+			usersClient.Health,
+			postsClient.Health,
+			sourceClient.Health,
+		},
 	}, nil
 }
 
-// Run starts up the server
-func (g GraphQLServer) Run() (func() error, error) {
-	//initialize session store
-	store := sessions.NewCookieStore([]byte(g.sessionkey))
-
+// Run starts up the server without blocking
+func (g *GraphQLServer) Run() (func(context.Context) error, error) {
 	//create router to inject middleware
 	router := chi.NewRouter()
-	router.Use(middleware.InjectSession(store))
+	router.Use(middleware.InjectRequestID())
+	router.Use(tracing.InjectTraceContext())
+	router.Use(middleware.InjectSession(g.sessionStore))
 	router.Use(middleware.InjectCors())
+	router.Use(g.tokens.InjectBearer())
+	router.Use(logging.InjectFields())
+	router.Use(dataloader.Middleware(g.fetchers))
 
 	//set up graphql endpoints
 	router.Handle("/graphql", playground.Handler("GraphQL playground", "/query"))
 	router.Handle("/query", g.server)
 
-	fullAddr := fmt.Sprintf("%s:%d", g.address, g.port)
-	fmt.Printf("Listening on %s\n", fullAddr)
-	err := http.ListenAndServe(fullAddr, router)
-	return func() error {
-		return errors.Wrap(err, "server ended")
-	}, nil
+	//set up sign in with oidc providers
+	g.oidc.Routes(router)
+
+	//set up probes
+	router.Get("/healthz", healthz)
+	router.Get("/readyz", g.readyz)
+
+	//set up operator endpoints on their own listener
+	admin := chi.NewRouter()
+	admin.Handle("/metrics", g.metrics.Handler())
+	if g.levelEndpoint {
+		admin.Handle("/loglevel", g.logger.LevelHandler())
+	}
+
+	adminServer, err := g.serve("admin", fmt.Sprintf("%s:%d", g.adminAddress, g.adminPort), admin)
+	if err != nil {
+		return nil, err
+	}
+	g.adminServer = adminServer
+	httpServer, err := g.serve("server", fmt.Sprintf("%s:%d", g.address, g.port), router)
+	if err != nil {
+		adminServer.Close()
+		return nil, err
+	}
+	g.httpServer = httpServer
+	atomic.StoreInt32(&g.ready, 1)
+	return g.shutdown(), nil
+}
+
+// serve starts serving the handler on the address without blocking
+func (g *GraphQLServer) serve(name, fullAddr string, routes http.Handler) (*http.Server, error) {
+	// listen up front so that failing to bind fails the start up
+	listener, err := net.Listen("tcp", fullAddr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on %s", fullAddr)
+	}
+	server := &http.Server{Handler: routes}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			// the first failure is enough to stop the gateway, so a later one is dropped
+			// rather than blocking once nobody is reading
+			select {
+			case g.errs <- errors.Wrapf(err, "%s ended", name):
+			default:
+			}
+		}
+	}()
+	g.logger.Info("listening", zap.String("listener", name), zap.String("address", fullAddr))
+	return server, nil
+}
+
+// Errors reports the server failing after it has started
+func (g *GraphQLServer) Errors() <-chan error {
+	return g.errs
+}
+
+// shutdown stops accepting connections and drains in flight requests until the context is done
+func (g *GraphQLServer) shutdown() func(context.Context) error {
+	return func(ctx context.Context) error {
+		// fail readiness first so no new traffic is routed here while draining
+		atomic.StoreInt32(&g.ready, 0)
+		if g.drainDelay > 0 {
+			g.logger.Info("draining before shutdown", zap.Duration("delay", g.drainDelay))
+			select {
+			case <-time.After(g.drainDelay):
+			case <-ctx.Done():
+			}
+		}
+		if err := g.httpServer.Shutdown(ctx); err != nil {
+			return errors.Wrap(err, "failed to gracefully shut down the server")
+		}
+		// the admin listener goes last so metrics can be scraped while draining
+		if err := g.adminServer.Shutdown(ctx); err != nil {
+			return errors.Wrap(err, "failed to gracefully shut down the admin server")
+		}
+		return nil
+	}
 }
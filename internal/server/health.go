@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/srcabl/gateway/internal/services"
+)
+
+// readyCheckTimeout bounds how long the readiness probe waits on the upstreams
+const readyCheckTimeout = 2 * time.Second
+
+// readiness is the body returned by the readiness endpoint
+type readiness struct {
+	Ready     bool              `json:"ready"`
+	Reason    string            `json:"reason,omitempty"`
+	Upstreams []services.Health `json:"upstreams"`
+}
+
+// healthz reports that the process is alive
+func healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// readyz reports whether the gateway and every upstream are ready to serve
+func (g *GraphQLServer) readyz(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&g.ready) == 0 {
+		writeJSON(w, http.StatusServiceUnavailable, readiness{Reason: "shutting down", Upstreams: []services.Health{}})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), readyCheckTimeout)
+	defer cancel()
+	res := readiness{Ready: true, Upstreams: make([]services.Health, len(g.upstreams))}
+	var wg sync.WaitGroup
+	for i, check := range g.upstreams {
+		wg.Add(1)
+		go func(i int, check func(context.Context) services.Health) {
+			defer wg.Done()
+			res.Upstreams[i] = check(ctx)
+		}(i, check)
+	}
+	wg.Wait()
+
+	status := http.StatusOK
+	for _, u := range res.Upstreams {
+		if !u.Healthy {
+			res.Ready = false
+			res.Reason = "upstream not ready"
+			status = http.StatusServiceUnavailable
+		}
+	}
+	writeJSON(w, status, res)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/middleware"
+	"github.com/srcabl/gateway/internal/token"
+	"github.com/srcabl/gateway/internal/util"
+)
+
+// authenticateWebsocket only lets a websocket through init when its upgrade request
+// carried the session cookie of a logged in user, or its init payload carries an
+// access token. The user stays on the context for the life of the websocket, so
+// subscriptions see the same user.
+func authenticateWebsocket(tokens *token.Tokens) transport.WebsocketInitFunc {
+	return func(ctx context.Context, payload transport.InitPayload) (context.Context, error) {
+		if access := token.FromHeader(payload.Authorization()); access != "" {
+			userUUID, err := tokens.Authenticate(access)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to authenticate access token")
+			}
+			ctx = util.SetTokenUserUUIDToContext(ctx, userUUID)
+		}
+		if util.GetUserUUIDFromContext(ctx) == nil {
+			return nil, errors.New("not logged in")
+		}
+		return ctx, nil
+	}
+}
+
+// checkWebsocketOrigin only lets browsers upgrade from the origins CORS allows
+func checkWebsocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || middleware.AllowedOrigin(origin)
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/logging"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// roundRobinServiceConfig spreads calls across every address the target resolves to
+const roundRobinServiceConfig = `{"loadBalancingConfig":[{"round_robin":{}}]}`
+
+// Dialer dials the upstreams with the options every client shares, such as interceptors
+type Dialer struct {
+	logger *logging.Logger
+	opts   []grpc.DialOption
+}
+
+// NewDialer news up a dialer
+func NewDialer(logger *logging.Logger, opts ...grpc.DialOption) *Dialer {
+	return &Dialer{
+		logger: logger.Named("dialer"),
+		opts:   opts,
+	}
+}
+
+// dial connects to the named upstream. The connection is established lazily
+// so an upstream that is down does not stop the gateway from starting.
+func (d *Dialer) dial(name string, upstream config.Upstream) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{
+		grpc.WithDefaultServiceConfig(roundRobinServiceConfig),
+	}
+	opts = append(opts, d.opts...)
+
+	if upstream.TLS.Enabled {
+		tlsConfig, err := upstreamTLSConfig(upstream.TLS)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to configure tls for %s", name)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	target := upstream.Target
+	if len(upstream.Addresses) > 0 {
+		// each upstream gets its own scheme so the static resolvers never collide
+		scheme := fmt.Sprintf("static-%s", name)
+		r := manual.NewBuilderWithScheme(scheme)
+		addrs := make([]resolver.Address, 0, len(upstream.Addresses))
+		for _, addr := range upstream.Addresses {
+			addrs = append(addrs, resolver.Address{Addr: addr})
+		}
+		r.InitialState(resolver.State{Addresses: addrs})
+		opts = append(opts, grpc.WithResolvers(r))
+		target = fmt.Sprintf("%s:///%s", scheme, name)
+	}
+
+	d.logger.Info("starting client connection", zap.String("upstream", name), zap.String("target", target))
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial %s at %s", name, target)
+	}
+	return conn, nil
+}
+
+// upstreamTLSConfig builds the tls config for an upstream, presenting a client
+// certificate when one is configured
+func upstreamTLSConfig(cfg config.UpstreamTLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: cfg.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if cfg.CAFile != "" {
+		pem, err := ioutil.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read ca file %s", cfg.CAFile)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no certificates found in ca file %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, errors.New("both cert_file and key_file are required for mtls")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
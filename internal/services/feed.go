@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/graph/model"
+	"github.com/srcabl/gateway/internal/pagination"
+	"github.com/srcabl/gateway/internal/util"
+	sharedpb "github.com/srcabl/protos/shared"
+	"go.uber.org/zap"
+)
+
+// feedPage is the part of a feed page read for one followed user or source
+type feedPage struct {
+	posts   []*sharedpb.Post
+	links   []*sharedpb.Link
+	hasMore bool
+}
+
+// feedFetch reads the part of a feed page for one followed user or source
+type feedFetch func(context.Context) (*feedPage, error)
+
+// Feed handles paginating the current user's home feed. It is the posts of the
+// users they follow and the posts whose links cite the sources they follow,
+// newest first, with each link only shown once: the cursors carry the links
+// shown so far, so later pages skip them too.
+func (c *postsClient) Feed(ctx context.Context, args pagination.Args) (*model.PostConnection, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == nil {
+		return nil, errors.New("no user found")
+	}
+	page, argErr := c.pager.Page(args)
+	if argErr != nil {
+		return model.ErrorToPostConnection(model.NewFieldError(argErr.Field, argErr.Message)), nil
+	}
+	fetches, err := c.feedFetches(ctx, userUUID, page)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the feed for current user")
+	}
+	pages, failed := c.fetchFeed(ctx, fetches)
+	conn := mergeFeed(pages, page, c.feedMaxSeen)
+	for _, p := range pages {
+		if p != nil {
+			c.primeLinks(ctx, p.links)
+		}
+	}
+	if failed > 0 {
+		conn.Errors = append(conn.Errors, model.NewFieldError("feed", "some followed users or sources could not be read"))
+	}
+	return conn, nil
+}
+
+// feedFetches reads who the user follows and makes a fetch for each followed user and source
+func (c *postsClient) feedFetches(ctx context.Context, userUUID []byte, page pagination.Page) ([]feedFetch, error) {
+	userUUIDs, sourceUUIDs, err := c.followed(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	c.logger.Ctx(ctx).Debug("reading feed",
+		zap.Int("followed_users", len(userUUIDs)),
+		zap.Int("followed_sources", len(sourceUUIDs)),
+	)
+	// each followed user and source is a call to the posts service, so only so many are read
+	if len(userUUIDs) > c.feedMaxFollowed {
+		userUUIDs = userUUIDs[:c.feedMaxFollowed]
+	}
+	if len(userUUIDs)+len(sourceUUIDs) > c.feedMaxFollowed {
+		sourceUUIDs = sourceUUIDs[:c.feedMaxFollowed-len(userUUIDs)]
+	}
+	fetches := make([]feedFetch, 0, len(userUUIDs)+len(sourceUUIDs))
+	for _, followedUUID := range userUUIDs {
+		req := model.PageToPBListUsersPostsRequest(followedUUID, page)
+		fetches = append(fetches, func(ctx context.Context) (*feedPage, error) {
+			res, err := c.postsService.ListUsersPosts(ctx, req)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list followed user's posts")
+			}
+			return &feedPage{posts: res.Posts, links: res.Links, hasMore: res.HasMore}, nil
+		})
+	}
+	for _, sourceUUID := range sourceUUIDs {
+		req := model.PageToPBListSourcesPostsRequest(sourceUUID, page)
+		fetches = append(fetches, func(ctx context.Context) (*feedPage, error) {
+			res, err := c.postsService.ListSourcesPosts(ctx, req)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list followed source's posts")
+			}
+			return &feedPage{posts: res.Posts, links: res.Links, hasMore: res.HasMore}, nil
+		})
+	}
+	return fetches, nil
+}
+
+// followed gets the uuids of the users and of the sources the user follows
+func (c *postsClient) followed(ctx context.Context, userUUID []byte) ([][]byte, [][]byte, error) {
+	usersRes, err := c.usersClient.Service().ListFollowedUsers(ctx, model.UserUUIDToPBListFollowedUsersRequest(userUUID))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list followed users")
+	}
+	sourcesRes, err := c.usersClient.Service().ListFollowedSources(ctx, model.UserUUIDToPBListFollowedSourcesRequest(userUUID))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list followed sources")
+	}
+	userUUIDs := make([][]byte, 0, len(usersRes.Users))
+	for _, u := range usersRes.Users {
+		userUUIDs = append(userUUIDs, u.Uuid)
+	}
+	return userUUIDs, sourcesRes.SourceUuids, nil
+}
+
+// fetchFeed runs the fetches concurrently, never more than the configured number at once.
+// It returns the pages in the order of the fetches, with nil for those that failed.
+func (c *postsClient) fetchFeed(ctx context.Context, fetches []feedFetch) ([]*feedPage, int) {
+	pages := make([]*feedPage, len(fetches))
+	errs := make([]error, len(fetches))
+	sem := make(chan struct{}, c.feedConcurrency)
+	var wg sync.WaitGroup
+	for i, fetch := range fetches {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, fetch feedFetch) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			pages[i], errs[i] = fetch(ctx)
+		}(i, fetch)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+			c.logger.Ctx(ctx).Warn("failed to read part of the feed", zap.Error(err))
+		}
+	}
+	return pages, failed
+}
+
+// mergeFeed merges the parts of a feed page into the page, keeping the newest post of each
+// link not already seen on an earlier page
+func mergeFeed(pages []*feedPage, page pagination.Page, maxSeen int) *model.PostConnection {
+	var posts []*sharedpb.Post
+	var links []*sharedpb.Link
+	hasMore := false
+	for _, p := range pages {
+		if p == nil {
+			continue
+		}
+		posts = append(posts, p.posts...)
+		links = append(links, p.links...)
+		hasMore = hasMore || p.hasMore
+	}
+	model.SortPBPosts(posts)
+	var shown []string
+	if page.After != nil {
+		shown = append(shown, page.After.Seen...)
+	}
+	if page.Before != nil {
+		shown = append(shown, page.Before.Seen...)
+	}
+	// a post can come from both its author and a source it cites, so de-duplicating
+	// by link also drops the same post read twice
+	seen := make(map[string]bool, len(shown)+len(posts))
+	for _, s := range shown {
+		seen[s] = true
+	}
+	unique := make([]*sharedpb.Post, 0, len(posts))
+	for _, p := range posts {
+		link := pagination.SeenLink(p.LinkUuid)
+		if seen[link] {
+			continue
+		}
+		seen[link] = true
+		unique = append(unique, p)
+	}
+	if len(unique) > page.Limit {
+		hasMore = true
+		if page.FromEnd {
+			unique = unique[len(unique)-page.Limit:]
+		} else {
+			unique = unique[:page.Limit]
+		}
+	}
+	return model.PBFeedPostsToPostConnection(unique, links, page, hasMore, shown, maxSeen)
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Health is the health of a single upstream
+type Health struct {
+	Name string `json:"name"`
+	// State is the connectivity state of the grpc connection
+	State string `json:"state"`
+	// Status is the serving status reported by the grpc health protocol
+	Status  string `json:"status"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// checkHealth asks the upstream behind conn for its health using the standard
+// grpc health protocol and combines it with the connection state
+func checkHealth(ctx context.Context, name string, conn *grpc.ClientConn) Health {
+	health := Health{Name: name}
+	if conn == nil {
+		health.Error = "not connected"
+		return health
+	}
+	state := conn.GetState()
+	health.State = state.String()
+	if state == connectivity.Shutdown {
+		health.Error = "connection is shut down"
+		return health
+	}
+
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.Status = res.GetStatus().String()
+	health.Healthy = res.GetStatus() == healthpb.HealthCheckResponse_SERVING
+	return health
+}
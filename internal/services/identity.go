@@ -0,0 +1,126 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/graph/model"
+	"github.com/srcabl/gateway/internal/oidc"
+	"github.com/srcabl/gateway/internal/util"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// usernameAttempts is how many usernames are tried when creating a user for an identity
+const usernameAttempts = 5
+
+// SignInWithIdentity handles signing in with an account at an identity provider. The
+// identity is linked to the logged in user, or when nobody is logged in to the user it
+// is already linked to, the user with the same verified email if the provider is
+// trusted for it, or a new user.
+func (c *usersClient) SignInWithIdentity(ctx context.Context, identity oidc.Identity) error {
+	current := util.GetUserUUIDFromContext(ctx)
+	res, err := c.usersClient.GetUserByIdentity(ctx, model.IdentityToPBGetUserByIdentityRequest(identity))
+	if err != nil && status.Code(err) != codes.NotFound {
+		return errors.Wrap(err, "failed to get user by identity")
+	}
+	if res != nil && res.User != nil {
+		if current != nil && !bytes.Equal(current, res.User.Uuid) {
+			return oidc.ErrIdentityLinked
+		}
+		if err := util.SetUserUUIDToContext(ctx, res.User.Uuid); err != nil {
+			return errors.Wrap(err, "failed to sign in")
+		}
+		return nil
+	}
+
+	userUUID := current
+	if userUUID == nil && identity.LinkByEmail && identity.EmailVerified && identity.Email != "" {
+		res, err := c.usersClient.GetUser(ctx, model.EmailToPBGetUserRequest(identity.Email))
+		if err != nil && status.Code(err) != codes.NotFound {
+			return errors.Wrap(err, "failed to get user by email")
+		}
+		if res != nil && res.User != nil {
+			userUUID = res.User.Uuid
+		}
+	}
+	if userUUID == nil {
+		if userUUID, err = c.createIdentityUser(ctx, identity); err != nil {
+			return err
+		}
+	}
+	if _, err := c.usersClient.LinkIdentity(ctx, model.IdentityToPBLinkIdentityRequest(userUUID, identity)); err != nil {
+		return errors.Wrap(err, "failed to link identity")
+	}
+	c.security.Ctx(ctx).Info("identity linked",
+		zap.String("event", "identity_linked"),
+		zap.String("provider", identity.Provider),
+		zap.String("user_id", uuid.FromBytesOrNil(userUUID).String()),
+	)
+	if err := util.SetUserUUIDToContext(ctx, userUUID); err != nil {
+		return errors.Wrap(err, "failed to sign in")
+	}
+	return nil
+}
+
+// createIdentityUser creates a user for the identity, named after it, adding a number
+// when the name is taken. Only an email the provider has verified is kept, and when it
+// already belongs to a user no user is created, as trying other usernames would not help.
+func (c *usersClient) createIdentityUser(ctx context.Context, identity oidc.Identity) ([]byte, error) {
+	if identity.EmailVerified && identity.Email != "" {
+		res, err := c.usersClient.GetUser(ctx, model.EmailToPBGetUserRequest(identity.Email))
+		if err != nil && status.Code(err) != codes.NotFound {
+			return nil, errors.Wrap(err, "failed to get user by email")
+		}
+		if res != nil && res.User != nil {
+			return nil, oidc.ErrEmailTaken
+		}
+	}
+	base := identityUsername(identity)
+	username := base
+	for attempt := 1; ; attempt++ {
+		userReq, err := model.IdentityToPBCreateUserRequest(identity, username)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to transform identity to grpc request")
+		}
+		res, err := c.usersClient.CreateUser(ctx, userReq)
+		if err == nil {
+			return res.User.Uuid, nil
+		}
+		if status.Code(err) != codes.AlreadyExists || attempt == usernameAttempts {
+			return nil, errors.Wrap(err, "failed to create user for identity")
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate username")
+		}
+		username = fmt.Sprintf("%s%04d", base, n.Int64())
+	}
+}
+
+// identityUsername names a user after the identity's preferred username or email,
+// keeping only letters, digits, dots, dashes and underscores
+func identityUsername(identity oidc.Identity) string {
+	name := identity.PreferredUsername
+	if name == "" {
+		name = strings.SplitN(identity.Email, "@", 2)[0]
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		return -1
+	}, name)
+	if name == "" {
+		name = "user"
+	}
+	return name
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/graph/model"
+)
+
+// loaderKeys are the ids a dataloader asked for, parsed to uuid bytes
+type loaderKeys struct {
+	// uuids lines up with the ids, with nil for ids that are not uuids
+	uuids [][]byte
+	// valid are the uuids worth asking the upstream for
+	valid [][]byte
+	// errs lines up with the ids, it is nil when every id is a uuid
+	errs []error
+}
+
+// parseLoaderKeys parses the ids a dataloader asked for, recording an error for each id that is not a uuid
+func parseLoaderKeys(ids []string) loaderKeys {
+	keys := loaderKeys{uuids: make([][]byte, len(ids))}
+	for i, id := range ids {
+		u, err := uuid.FromString(id)
+		if err != nil {
+			if keys.errs == nil {
+				keys.errs = make([]error, len(ids))
+			}
+			keys.errs[i] = errors.Errorf("%s is not a valid id", id)
+			continue
+		}
+		keys.uuids[i] = u.Bytes()
+		keys.valid = append(keys.valid, u.Bytes())
+	}
+	return keys
+}
+
+// setErr records that the value for the id at i could not be converted
+func (k *loaderKeys) setErr(i int, err *model.Error) {
+	if k.errs == nil {
+		k.errs = make([]error, len(k.uuids))
+	}
+	k.errs[i] = errors.New(*err.Message)
+}
@@ -1,50 +1,89 @@
 package services
 
 import (
+	"bytes"
 	"context"
-	"fmt"
-	"log"
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	"github.com/srcabl/gateway/graph/model"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/dataloader"
+	"github.com/srcabl/gateway/internal/logging"
+	"github.com/srcabl/gateway/internal/pagination"
+	"github.com/srcabl/gateway/internal/pubsub"
 	"github.com/srcabl/gateway/internal/util"
 	postspb "github.com/srcabl/protos/posts"
 	sharedpb "github.com/srcabl/protos/shared"
-	"github.com/srcabl/services/pkg/config"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
 
 // PostsClient defeines the behavior of a posts client
 type PostsClient interface {
-	Run() (func() error, error)
+	Run() (func(context.Context) error, error)
+	Health(context.Context) Health
 	CreatePost(context.Context, model.CreatePostRequest) (*model.CommonPostResponse, error)
-	Posts(context.Context, model.PostsRequest) (*model.CommonPostsResponse, error)
-	CurrentUsersPosts(context.Context) (*model.CommonPostsResponse, error)
+	UpdatePost(context.Context, model.UpdatePostRequest) (*model.CommonPostResponse, error)
+	DeletePost(context.Context, model.DeletePostRequest) (*model.CommonPostResponse, error)
+	Posts(context.Context, model.PostsRequest, pagination.Args) (*model.PostConnection, error)
+	CurrentUsersPosts(context.Context, pagination.Args) (*model.PostConnection, error)
+	Feed(context.Context, pagination.Args) (*model.PostConnection, error)
+	LinkByID(context.Context, string) (*model.PartialLink, error)
+	LinkSourceGraph(context.Context, string, *int) (*model.SourceGraph, error)
+	Link(context.Context, model.LinkRequest) (*model.CommonLinkResponse, error)
+	PreviewLink(context.Context, string) (*model.CommonLinkPreviewResponse, error)
+	PostCreated(context.Context, string) (<-chan *model.PartialPost, error)
+	FeedUpdated(context.Context) (<-chan *model.PartialPost, error)
+	PostDeleted(context.Context) (<-chan *model.PartialPost, error)
+	//dataloader fetchers
+	GetLinksByID(context.Context, []string) ([]*model.PartialLink, []error)
 }
 
 type postsClient struct {
-	postsPort    int
-	postsConn    *grpc.ClientConn
-	postsService postspb.PostsServiceClient
+	logger        *logging.Logger
+	dialer        *Dialer
+	postsUpstream config.Upstream
+	postsConn     *grpc.ClientConn
+	postsService  postspb.PostsServiceClient
+	pager         *pagination.Pager
+	// feedFetches bounds how many followed users and sources a feed reads at once
+	feedConcurrency int
+	// feedMaxFollowed bounds how many followed users and sources a feed page reads
+	feedMaxFollowed int
+	// feedMaxSeen bounds how many of the links already shown a feed cursor remembers
+	feedMaxSeen int
+	// sourceCache keeps the sources determined for previewed links
+	sourceCache *sourceCache
+	// bus carries post events to subscriptions
+	bus pubsub.Bus
 
 	sourcesClient SourcesClient
+	usersClient   UsersClient
 }
 
 // NewPostsClient news up the posts client
-func NewPostsClient(config *config.Gateway, sourcesClient SourcesClient) (PostsClient, error) {
+func NewPostsClient(config *config.Gateway, logger *logging.Logger, dialer *Dialer, sourcesClient SourcesClient, usersClient UsersClient, bus pubsub.Bus) (PostsClient, error) {
 	return &postsClient{
-		postsPort:     config.Services.PostsPort,
-		sourcesClient: sourcesClient,
+		logger:          logger.Named("posts"),
+		dialer:          dialer,
+		postsUpstream:   config.Upstreams.Posts,
+		pager:           pagination.NewPager(config.Pagination),
+		feedConcurrency: config.Feed.MaxConcurrentFetches,
+		feedMaxFollowed: config.Feed.MaxFollowed,
+		feedMaxSeen:     config.Feed.MaxSeenLinks,
+		sourceCache:     newSourceCache(config.LinkPreview.CacheTTL, config.LinkPreview.CacheSize),
+		bus:             bus,
+		sourcesClient:   sourcesClient,
+		usersClient:     usersClient,
 	}, nil
 }
 
 // Run starts up the clients
-func (c *postsClient) Run() (func() error, error) {
-	log.Printf("Starting Posts Client Connection on: %d\n", c.postsPort)
-	postsConn, err := grpc.Dial(fmt.Sprintf("localhost:%d", c.postsPort), grpc.WithInsecure())
+func (c *postsClient) Run() (func(context.Context) error, error) {
+	postsConn, err := c.dialer.dial("posts", c.postsUpstream)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to dial to posts port: %d", c.postsPort)
+		return nil, errors.Wrap(err, "failed to dial to posts")
 	}
 	c.postsConn = postsConn
 	c.postsService = postspb.NewPostsServiceClient(postsConn)
@@ -52,9 +91,14 @@ func (c *postsClient) Run() (func() error, error) {
 	return c.close(), nil
 }
 
+// Health checks the health of the posts service
+func (c *postsClient) Health(ctx context.Context) Health {
+	return checkHealth(ctx, "posts", c.postsConn)
+}
+
 // Close closes the grpc connection
-func (c *postsClient) close() func() error {
-	return func() error {
+func (c *postsClient) close() func(context.Context) error {
+	return func(ctx context.Context) error {
 		if err := c.postsConn.Close(); err != nil {
 			return errors.Wrap(err, "failed to close posts connection")
 		}
@@ -64,106 +108,348 @@ func (c *postsClient) close() func() error {
 
 // CreatePost handles creating a post
 func (c *postsClient) CreatePost(ctx context.Context, input model.CreatePostRequest) (*model.CommonPostResponse, error) {
-	fmt.Println("In create post")
 	userUUID := util.GetUserUUIDFromContext(ctx)
 	if userUUID == nil {
-		fmt.Printf("No user\n")
+		c.logger.Ctx(ctx).Debug("create post without a user")
 		return nil, errors.New("user not determined")
 	}
+	link, urlErr, err := c.resolveLink(ctx, input.URL)
+	if err != nil {
+		return nil, err
+	}
+	if urlErr != nil {
+		return &model.CommonPostResponse{
+			Errors: []*model.Error{urlErr},
+		}, nil
+	}
+	createPostReq := model.CreatePostRequestToPBCreatePostRequest(input, userUUID, link.Uuid)
+	createPostRes, resErr := c.postsService.CreatePost(ctx, createPostReq)
+	if resErr != nil {
+		c.logger.Ctx(ctx).Warn("failed to create post", zap.Error(resErr))
+	} else if createPostRes.GetPost() != nil {
+		c.publish(ctx, pubsub.PostCreated, createPostRes.Post, link)
+	}
+	return model.PBCreatePostLinkResponseToCommonPostResponse(createPostRes, link, resErr), nil
+}
+
+// UpdatePost handles updating a post owned by the current user
+func (c *postsClient) UpdatePost(ctx context.Context, input model.UpdatePostRequest) (*model.CommonPostResponse, error) {
+	post, link, commonErr, err := c.getOwnedPost(ctx, input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	if commonErr != nil {
+		return &model.CommonPostResponse{
+			Errors: []*model.Error{commonErr},
+		}, nil
+	}
+	// only re-resolve the link and its sources when the url changes
+	if link == nil || link.Url != input.URL {
+		var urlErr *model.Error
+		link, urlErr, err = c.resolveLink(ctx, input.URL)
+		if err != nil {
+			return nil, err
+		}
+		if urlErr != nil {
+			return &model.CommonPostResponse{
+				Errors: []*model.Error{urlErr},
+			}, nil
+		}
+	}
+	updatePostReq := model.UpdatePostRequestToPBUpdatePostRequest(input, post.Uuid, link.Uuid)
+	updatePostRes, resErr := c.postsService.UpdatePost(ctx, updatePostReq)
+	if resErr == nil && updatePostRes.GetPost() != nil {
+		c.publish(ctx, pubsub.PostUpdated, updatePostRes.Post, link)
+	}
+	return model.PBUpdatePostLinkResponseToCommonPostResponse(updatePostRes, link, resErr), nil
+}
+
+// DeletePost handles deleting a post owned by the current user
+func (c *postsClient) DeletePost(ctx context.Context, input model.DeletePostRequest) (*model.CommonPostResponse, error) {
+	post, link, commonErr, err := c.getOwnedPost(ctx, input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	if commonErr != nil {
+		return &model.CommonPostResponse{
+			Errors: []*model.Error{commonErr},
+		}, nil
+	}
+	deletePostReq := model.DeletePostRequestToPBDeletePostRequest(post.Uuid)
+	if _, err := c.postsService.DeletePost(ctx, deletePostReq); err != nil {
+		return &model.CommonPostResponse{
+			Errors: []*model.Error{model.PBResponseErrorToError(err)},
+		}, nil
+	}
+	c.publish(ctx, pubsub.PostDeleted, post, link)
+	// respond with the post as it was before deletion
+	return model.PBGetPostLinkResponseToCommonPostResponse(&postspb.GetPostResponse{Post: post}, link, nil), nil
+}
+
+// getOwnedPost gets the post and its link, making sure it belongs to the current user
+func (c *postsClient) getOwnedPost(ctx context.Context, postID string) (*sharedpb.Post, *sharedpb.Link, *model.Error, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == nil {
+		return nil, nil, nil, errors.New("user not determined")
+	}
+	postUUID, err := uuid.FromString(postID)
+	if err != nil {
+		return nil, nil, model.NewFieldError("postID", "post id is not valid"), nil
+	}
+	res, err := c.postsService.GetPost(ctx, model.PostIDToPBGetPostRequest(postUUID.Bytes()))
+	if err != nil || res.Post == nil {
+		return nil, nil, model.NewFieldError("postID", "post does not exist"), nil
+	}
+	if !bytes.Equal(res.Post.UserUuid, userUUID) {
+		return nil, nil, model.NewFieldError("postID", "post does not belong to the current user"), nil
+	}
+	return res.Post, res.Link, nil, nil
+}
+
+// resolveLink gets the link for the url, determining its sources and creating it if it does
+// not exist. A url that must not be fetched is returned as a field error.
+func (c *postsClient) resolveLink(ctx context.Context, url string) (*sharedpb.Link, *model.Error, error) {
+	if urlErr := linkURLError(url); urlErr != nil {
+		return nil, urlErr, nil
+	}
+	input := model.CreatePostRequest{URL: url}
 	// Check if link exists
-	var link *sharedpb.Link
 	getLinkByURLReq := model.GetLinkByURLRequest(input)
 	linkRes, err := c.postsService.GetLink(ctx, getLinkByURLReq)
-	fmt.Printf("GetLinkRes: %+v\n", linkRes)
-	fmt.Printf("GetLinkError: %+v\n", err)
-	if err == nil {
-		link = linkRes.Link
-	}
-	if link == nil {
-		fmt.Println("Creating link")
-		// if not, determine
-		determineSourceReq := model.CreatePostRequestToPBDetermineSourceRequest(input)
-		source, err := c.sourcesClient.Service().DetermineLinkSource(ctx, determineSourceReq)
-		if err != nil {
-			fmt.Printf("Source Error: %+v\n", err)
-			return nil, errors.Wrapf(err, "failed to determine the source of %s", input.URL)
+	if err == nil && linkRes.Link != nil {
+		return linkRes.Link, nil, nil
+	}
+	logger := c.logger.Ctx(ctx).With(zap.String("url", url))
+	logger.Debug("link does not exist, creating it", zap.NamedError("get_link_error", err))
+	// if not, determine
+	sourceNodes, err := c.determineSources(ctx, url)
+	if err != nil {
+		return nil, nil, err
+	}
+	createLinkReq := model.CreatePostRequestToPBCreateLinkRequest(input, sourceNodes)
+	createLinkRes, err := c.postsService.CreateLink(ctx, createLinkReq)
+	if err != nil {
+		logger.Warn("failed to create link", zap.Error(err))
+		return nil, nil, errors.Wrap(err, "failed to create link")
+	}
+	return createLinkRes.Link, nil, nil
+}
+
+// linkURLError checks the url is one the sources service may fetch, as every link
+// that is posted or previewed has its sources determined from it
+func linkURLError(url string) *model.Error {
+	if isvalid, message := util.ValidateLinkURLRequirements(url); !isvalid {
+		return model.NewFieldError("url", message)
+	}
+	return nil
+}
+
+// determineSources determines the primary sources of the url, reusing those recently
+// determined for a preview of it
+func (c *postsClient) determineSources(ctx context.Context, url string) ([]*sharedpb.SourceNode, error) {
+	logger := c.logger.Ctx(ctx).With(zap.String("url", url))
+	if nodes, ok := c.sourceCache.get(url); ok {
+		logger.Debug("reusing determined link source", zap.Int("primary_source_nodes", len(nodes)))
+		return nodes, nil
+	}
+	determineSourceReq := model.CreatePostRequestToPBDetermineSourceRequest(model.CreatePostRequest{URL: url})
+	source, err := c.sourcesClient.Service().DetermineLinkSource(ctx, determineSourceReq)
+	if err != nil {
+		logger.Warn("failed to determine link source", zap.Error(err))
+		return nil, errors.Wrapf(err, "failed to determine the source of %s", url)
+	}
+	logger.Debug("determined link source", zap.Int("primary_source_nodes", len(source.PrimarySourceNodes)))
+	c.sourceCache.add(url, source.PrimarySourceNodes)
+	return source.PrimarySourceNodes, nil
+}
+
+// PreviewLink handles previewing what posting the url would link to. It reports whether
+// the link is already known and its primary sources, without creating anything. Only
+// logged in users may preview, as working out the sources of a new link fetches it.
+func (c *postsClient) PreviewLink(ctx context.Context, url string) (*model.CommonLinkPreviewResponse, error) {
+	if util.GetUserUUIDFromContext(ctx) == nil {
+		return &model.CommonLinkPreviewResponse{
+			Errors: []*model.Error{model.NewFieldError("", "not logged in")},
+		}, nil
+	}
+	if urlErr := linkURLError(url); urlErr != nil {
+		return &model.CommonLinkPreviewResponse{
+			Errors: []*model.Error{urlErr},
+		}, nil
+	}
+	input := model.CreatePostRequest{URL: url}
+	preview := &model.LinkPreview{URL: url}
+	linkRes, err := c.postsService.GetLink(ctx, model.GetLinkByURLRequest(input))
+	if err == nil && linkRes.Link != nil {
+		link, linkErr := model.PBLinkToPartialLink(linkRes.Link)
+		if linkErr != nil {
+			return &model.CommonLinkPreviewResponse{Errors: []*model.Error{linkErr}}, nil
 		}
-		fmt.Printf("\n\n\n\nDetermined Sources %+v\n", source)
-		createLinkReq := model.CreatePostRequestToPBCreateLinkRequest(input, source.PrimarySourceNodes)
-		fmt.Printf("CreateLinkReq %+v\n", createLinkReq)
-		createLinkRes, err := c.postsService.CreateLink(ctx, createLinkReq)
-		fmt.Printf("CreateLinkRes: %+v\n", createLinkRes)
-		fmt.Printf("CreateLinkError: %+v\n", err)
+		c.primeLinks(ctx, []*sharedpb.Link{linkRes.Link})
+		preview.Known = true
+		preview.Link = link
+		// a known link keeps the sources determined when it was created
+		loaders, err := dataloader.For(ctx)
 		if err != nil {
-			fmt.Printf("Create link Error: %+v\n", err)
-			return nil, errors.Wrap(err, "failed to create link")
+			return nil, err
 		}
-		link = createLinkRes.Link
+		sources, errs := loaders.SourcesByID.LoadAll(link.SourceIDs)
+		var resErrs []*model.Error
+		for i, source := range sources {
+			if errs[i] != nil {
+				resErrs = append(resErrs, model.NewFieldError("sources", errs[i].Error()))
+				continue
+			}
+			if source != nil {
+				preview.Sources = append(preview.Sources, source)
+			}
+		}
+		return &model.CommonLinkPreviewResponse{Errors: resErrs, Preview: preview}, nil
 	}
-	createPostReq := model.CreatePostRequestToPBCreatePostRequest(input, userUUID, link.Uuid)
-	createPostRes, resErr := c.postsService.CreatePost(ctx, createPostReq)
-	fmt.Printf("CreatePostRes: %+v\n", createPostRes)
-	fmt.Printf("CreatePostError: %+v\n", resErr)
-	postRes := model.PBCreatePostLinkResponseToCommonPostResponse(createPostRes, link, resErr)
-	fmt.Printf("Create Post Res: %+v\n", postRes)
-	return postRes, nil
+	sourceNodes, err := c.determineSources(ctx, url)
+	if err != nil {
+		return &model.CommonLinkPreviewResponse{
+			Errors: []*model.Error{model.NewFieldError("url", "could not determine the sources of the url")},
+		}, nil
+	}
+	sources, resErrs := model.PBSourceNodesToPartialSources(sourceNodes)
+	preview.Sources = sources
+	return &model.CommonLinkPreviewResponse{Errors: resErrs, Preview: preview}, nil
 }
 
-func (c *postsClient) CurrentUsersPosts(ctx context.Context) (*model.CommonPostsResponse, error) {
+// CurrentUsersPosts handles paginating the current user's posts
+func (c *postsClient) CurrentUsersPosts(ctx context.Context, args pagination.Args) (*model.PostConnection, error) {
 	// get the current user uuid
 	userUUID := util.GetUserUUIDFromContext(ctx)
 	if userUUID == nil {
 		return nil, errors.New("no user found")
 	}
-	fmt.Printf("userUUID: %+v", userUUID)
-	res, err := c.getPostsFromUser(ctx, userUUID)
+	res, err := c.getPostsFromUser(ctx, userUUID, args)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get posts for current user %s")
+		return nil, errors.Wrap(err, "failed to get posts for current user")
 	}
 	return res, nil
 }
 
-func (c *postsClient) Posts(ctx context.Context, input model.PostsRequest) (*model.CommonPostsResponse, error) {
+// Posts handles paginating a user's posts
+func (c *postsClient) Posts(ctx context.Context, input model.PostsRequest, args pagination.Args) (*model.PostConnection, error) {
 	userUUID, err := uuid.FromString(input.UserID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to convert session user id to uuid")
 	}
-	res, err := c.getPostsFromUser(ctx, userUUID.Bytes())
+	res, err := c.getPostsFromUser(ctx, userUUID.Bytes(), args)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get posts for user %s", input.UserID)
 	}
 	return res, nil
 }
 
-func (c *postsClient) getPostsFromUser(ctx context.Context, userID []byte) (*model.CommonPostsResponse, error) {
-	req := &postspb.ListUsersPostsRequest{UserUuid: userID}
-	fmt.Printf("req: %+v", req)
+func (c *postsClient) getPostsFromUser(ctx context.Context, userID []byte, args pagination.Args) (*model.PostConnection, error) {
+	page, argErr := c.pager.Page(args)
+	if argErr != nil {
+		return model.ErrorToPostConnection(model.NewFieldError(argErr.Field, argErr.Message)), nil
+	}
+	req := model.PageToPBListUsersPostsRequest(userID, page)
 	res, err := c.postsService.ListUsersPosts(ctx, req)
-	fmt.Printf("res: %+v", res)
 	if err != nil {
-		fmt.Printf("res error: %+v", err)
+		c.logger.Ctx(ctx).Warn("failed to list users posts", zap.Error(err))
 		return nil, errors.Wrap(err, "failed to get the posts for user")
 	}
-	fmt.Printf("posts: %+v", res.Posts)
-	var posts []*model.PartialPost
-	for i, p := range res.Posts {
-		postUUID, err := uuid.FromBytes(p.Uuid)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to convert post id to uuid")
+	c.logger.Ctx(ctx).Debug("listed users posts", zap.Int("count", len(res.Posts)), zap.Bool("has_more", res.HasMore))
+	c.primeLinks(ctx, res.Links)
+	return model.PBListUsersPostsResponseToPostConnection(res, page), nil
+}
+
+// primeLinks primes the request's link loader with links that came back alongside posts,
+// so resolving them later needs no more calls
+func (c *postsClient) primeLinks(ctx context.Context, links []*sharedpb.Link) {
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return
+	}
+	for _, l := range links {
+		if partlink, linkErr := model.PBLinkToPartialLink(l); linkErr == nil {
+			loaders.LinksByID.Prime(partlink.ID, partlink)
 		}
-		userUUID, err := uuid.FromBytes(p.UserUuid)
+	}
+}
+
+// LinkByID handles resolving a link through the request's dataloader
+func (c *postsClient) LinkByID(ctx context.Context, id string) (*model.PartialLink, error) {
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	link, err := loaders.LinksByID.Load(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load link %s", id)
+	}
+	return link, nil
+}
+
+// LinkSourceGraph handles resolving the provenance of a link's sources through the request's dataloaders
+func (c *postsClient) LinkSourceGraph(ctx context.Context, linkID string, depth *int) (*model.SourceGraph, error) {
+	link, err := c.LinkByID(ctx, linkID)
+	if err != nil || link == nil {
+		return nil, err
+	}
+	return c.sourcesClient.SourceGraph(ctx, link.SourceIDs, depth)
+}
+
+// Link handles requests for a single link by its id or url
+func (c *postsClient) Link(ctx context.Context, input model.LinkRequest) (*model.CommonLinkResponse, error) {
+	if (input.ID == nil) == (input.URL == nil) {
+		return &model.CommonLinkResponse{
+			Errors: []*model.Error{model.NewFieldError("id", "exactly one of id or url is required")},
+		}, nil
+	}
+	if input.ID != nil {
+		link, err := c.LinkByID(ctx, *input.ID)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to convert user id to uuid")
+			return &model.CommonLinkResponse{
+				Errors: []*model.Error{model.NewFieldError("id", err.Error())},
+			}, nil
 		}
-		post := model.PartialPost{
-			ID:      postUUID.String(),
-			UserID:  userUUID.String(),
-			Comment: p.Comment.PrimaryContent,
-			LinkURL: res.Links[i].Url,
+		if link == nil {
+			return &model.CommonLinkResponse{
+				Errors: []*model.Error{model.NewFieldError("id", "link does not exist")},
+			}, nil
 		}
-		posts = append(posts, &post)
+		return &model.CommonLinkResponse{Link: link}, nil
 	}
-	return &model.CommonPostsResponse{
-		Posts: posts,
-	}, nil
+	res, resErr := c.postsService.GetLink(ctx, model.LinkRequestToPBGetLinkRequest(*input.URL))
+	if resErr == nil && res.GetLink() != nil {
+		c.primeLinks(ctx, []*sharedpb.Link{res.GetLink()})
+	}
+	return model.PBGetLinkResponseToCommonLinkResponse(res, resErr), nil
+}
+
+// GetLinksByID fetches the links with the given ids in a single call, in the order of the ids
+func (c *postsClient) GetLinksByID(ctx context.Context, ids []string) ([]*model.PartialLink, []error) {
+	keys := parseLoaderKeys(ids)
+	links := make([]*model.PartialLink, len(ids))
+	if len(keys.valid) == 0 {
+		return links, keys.errs
+	}
+	res, err := c.postsService.GetLinks(ctx, model.LinkUUIDsToPBGetLinksRequest(keys.valid))
+	if err != nil {
+		return nil, []error{errors.Wrap(err, "failed to get links")}
+	}
+	byUUID := make(map[string]*sharedpb.Link, len(res.Links))
+	for _, l := range res.Links {
+		byUUID[string(l.Uuid)] = l
+	}
+	for i, u := range keys.uuids {
+		link, ok := byUUID[string(u)]
+		if u == nil || !ok {
+			continue
+		}
+		partlink, linkErr := model.PBLinkToPartialLink(link)
+		if linkErr != nil {
+			keys.setErr(i, linkErr)
+			continue
+		}
+		links[i] = partlink
+	}
+	return links, keys.errs
 }
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/srcabl/gateway/graph/model"
+	"github.com/srcabl/gateway/internal/dataloader"
+	"go.uber.org/zap"
+)
+
+// SourceGraph handles walking the provenance of the given sources, the sources
+// they got their stories from and so on, up to depth levels. The given sources
+// are the first level. Every source appears once, so cycles end the walk.
+func (c *sourcesClient) SourceGraph(ctx context.Context, sourceIDs []string, depth *int) (*model.SourceGraph, error) {
+	graph := &model.SourceGraph{
+		Nodes: []*model.SourceNode{},
+		Edges: []*model.SourceEdge{},
+	}
+	maxDepth := c.maxDepth
+	if depth != nil {
+		if *depth < 1 {
+			graph.Errors = append(graph.Errors, model.NewFieldError("depth", "depth must be at least 1"))
+			return graph, nil
+		}
+		if *depth < maxDepth {
+			maxDepth = *depth
+		}
+	}
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	visited := make(map[string]bool, len(sourceIDs))
+	var level []string
+	for _, id := range sourceIDs {
+		if !visited[id] {
+			visited[id] = true
+			level = append(level, id)
+		}
+	}
+	for d := 1; len(level) > 0; d++ {
+		// each level is loaded in one batch
+		sources, errs := loaders.SourcesByID.LoadAll(level)
+		var next []string
+		for i, source := range sources {
+			if errs[i] != nil {
+				graph.Errors = append(graph.Errors, model.NewFieldError("sources", fmt.Sprintf("failed to load source %s", level[i])))
+				continue
+			}
+			if source == nil {
+				continue
+			}
+			graph.Nodes = append(graph.Nodes, &model.SourceNode{Source: source, Depth: d})
+			for _, parentID := range source.ParentIDs {
+				if !visited[parentID] {
+					if d == maxDepth {
+						graph.Truncated = true
+						continue
+					}
+					visited[parentID] = true
+					next = append(next, parentID)
+				}
+				graph.Edges = append(graph.Edges, &model.SourceEdge{From: source.ID, To: parentID})
+			}
+		}
+		level = next
+	}
+	c.logger.Ctx(ctx).Debug("walked source graph",
+		zap.Int("nodes", len(graph.Nodes)),
+		zap.Int("max_depth", maxDepth),
+		zap.Bool("truncated", graph.Truncated),
+	)
+	return graph, nil
+}
+
+// Source handles requests for a single source
+func (c *sourcesClient) Source(ctx context.Context, input model.SourceRequest) (*model.CommonSourceResponse, error) {
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	source, err := loaders.SourcesByID.Load(input.ID)
+	if err != nil {
+		return &model.CommonSourceResponse{
+			Errors: []*model.Error{model.NewFieldError("id", err.Error())},
+		}, nil
+	}
+	if source == nil {
+		return &model.CommonSourceResponse{
+			Errors: []*model.Error{model.NewFieldError("id", "source does not exist")},
+		}, nil
+	}
+	return &model.CommonSourceResponse{Sources: source}, nil
+}
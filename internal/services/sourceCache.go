@@ -0,0 +1,76 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	sharedpb "github.com/srcabl/protos/shared"
+)
+
+// sourceCache keeps the sources determined for a url for a short while, so a
+// link preview and the post that follows it only determine them once
+type sourceCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]cachedSources
+	now     func() time.Time
+}
+
+type cachedSources struct {
+	nodes   []*sharedpb.SourceNode
+	expires time.Time
+}
+
+// newSourceCache news up a source cache holding at most size urls for ttl each
+func newSourceCache(ttl time.Duration, size int) *sourceCache {
+	return &sourceCache{
+		ttl:     ttl,
+		size:    size,
+		entries: map[string]cachedSources{},
+		now:     time.Now,
+	}
+}
+
+// get gets the sources determined for the url, if they have not expired
+func (c *sourceCache) get(url string) ([]*sharedpb.SourceNode, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[url]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, url)
+		return nil, false
+	}
+	return entry.nodes, true
+}
+
+// add caches the sources determined for the url, making room if the cache is full
+func (c *sourceCache) add(url string, nodes []*sharedpb.SourceNode) {
+	if c.ttl <= 0 || c.size <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if _, ok := c.entries[url]; !ok && len(c.entries) >= c.size {
+		// clear out what has expired, then the entry closest to expiring if that was not enough
+		var oldest string
+		var oldestExpires time.Time
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+				continue
+			}
+			if oldest == "" || e.expires.Before(oldestExpires) {
+				oldest, oldestExpires = k, e.expires
+			}
+		}
+		if len(c.entries) >= c.size {
+			delete(c.entries, oldest)
+		}
+	}
+	c.entries[url] = cachedSources{nodes: nodes, expires: now.Add(c.ttl)}
+}
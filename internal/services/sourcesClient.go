@@ -1,41 +1,54 @@
 package services
 
 import (
-	"fmt"
-	"log"
+	"context"
 
 	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/graph/model"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/logging"
+	sharedpb "github.com/srcabl/protos/shared"
 	"github.com/srcabl/protos/sources"
 	sourcespb "github.com/srcabl/protos/sources"
-	"github.com/srcabl/services/pkg/config"
 	"google.golang.org/grpc"
 )
 
 // SourcesClient defines the behavior of a sources client
 type SourcesClient interface {
-	Run() (func() error, error)
+	Run() (func(context.Context) error, error)
+	Health(context.Context) Health
 	Service() sourcespb.SourcesServiceClient
+	Source(context.Context, model.SourceRequest) (*model.CommonSourceResponse, error)
+	SourceGraph(context.Context, []string, *int) (*model.SourceGraph, error)
+	//dataloader fetchers
+	GetSourcesByID(context.Context, []string) ([]*model.PartialSource, []error)
 }
 
 type sourcesClient struct {
-	sourcesPort    int
-	sourcesConn    *grpc.ClientConn
-	sourcesService sourcespb.SourcesServiceClient
+	logger          *logging.Logger
+	dialer          *Dialer
+	sourcesUpstream config.Upstream
+	sourcesConn     *grpc.ClientConn
+	sourcesService  sourcespb.SourcesServiceClient
+	// maxDepth is the deepest a source graph is walked
+	maxDepth int
 }
 
 // NewSourcesClient news up the sources client
-func NewSourcesClient(config *config.Gateway) (SourcesClient, error) {
+func NewSourcesClient(config *config.Gateway, logger *logging.Logger, dialer *Dialer) (SourcesClient, error) {
 	return &sourcesClient{
-		sourcesPort: config.Services.SourcesPort,
+		logger:          logger.Named("sources"),
+		dialer:          dialer,
+		sourcesUpstream: config.Upstreams.Sources,
+		maxDepth:        config.Provenance.MaxDepth,
 	}, nil
 }
 
 // Run starts up the clients
-func (c *sourcesClient) Run() (func() error, error) {
-	log.Printf("Starting Sources Client Connection on: %d\n", c.sourcesPort)
-	sourcesConn, err := grpc.Dial(fmt.Sprintf("localhost:%d", c.sourcesPort), grpc.WithInsecure())
+func (c *sourcesClient) Run() (func(context.Context) error, error) {
+	sourcesConn, err := c.dialer.dial("sources", c.sourcesUpstream)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to dial to sources port: %d", c.sourcesPort)
+		return nil, errors.Wrap(err, "failed to dial to sources")
 	}
 	c.sourcesConn = sourcesConn
 	c.sourcesService = sourcespb.NewSourcesServiceClient(sourcesConn)
@@ -43,11 +56,16 @@ func (c *sourcesClient) Run() (func() error, error) {
 	return c.close(), nil
 }
 
+// Health checks the health of the sources service
+func (c *sourcesClient) Health(ctx context.Context) Health {
+	return checkHealth(ctx, "sources", c.sourcesConn)
+}
+
 // Close closes the grpc connection
-func (c *sourcesClient) close() func() error {
-	return func() error {
+func (c *sourcesClient) close() func(context.Context) error {
+	return func(ctx context.Context) error {
 		if err := c.sourcesConn.Close(); err != nil {
-			return errors.Wrap(err, "failed to close posts connection")
+			return errors.Wrap(err, "failed to close sources connection")
 		}
 		return nil
 	}
@@ -56,3 +74,33 @@ func (c *sourcesClient) close() func() error {
 func (c *sourcesClient) Service() sources.SourcesServiceClient {
 	return c.sourcesService
 }
+
+// GetSourcesByID fetches the sources with the given ids in a single call, in the order of the ids
+func (c *sourcesClient) GetSourcesByID(ctx context.Context, ids []string) ([]*model.PartialSource, []error) {
+	keys := parseLoaderKeys(ids)
+	sources := make([]*model.PartialSource, len(ids))
+	if len(keys.valid) == 0 {
+		return sources, keys.errs
+	}
+	res, err := c.sourcesService.GetSources(ctx, model.SourceUUIDsToPBGetSourcesRequest(keys.valid))
+	if err != nil {
+		return nil, []error{errors.Wrap(err, "failed to get sources")}
+	}
+	byUUID := make(map[string]*sharedpb.Source, len(res.Sources))
+	for _, s := range res.Sources {
+		byUUID[string(s.Uuid)] = s
+	}
+	for i, u := range keys.uuids {
+		source, ok := byUUID[string(u)]
+		if u == nil || !ok {
+			continue
+		}
+		partsource, sourceErr := model.PBSourceToPartialSource(source)
+		if sourceErr != nil {
+			keys.setErr(i, sourceErr)
+			continue
+		}
+		sources[i] = partsource
+	}
+	return sources, keys.errs
+}
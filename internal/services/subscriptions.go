@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/graph/model"
+	"github.com/srcabl/gateway/internal/pubsub"
+	"github.com/srcabl/gateway/internal/util"
+	sharedpb "github.com/srcabl/protos/shared"
+	"go.uber.org/zap"
+)
+
+// PostCreated handles subscribing to the posts a user creates
+func (c *postsClient) PostCreated(ctx context.Context, userID string) (<-chan *model.PartialPost, error) {
+	userUUID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, errors.Errorf("%s is not a valid id", userID)
+	}
+	return c.subscribe(ctx, func(e pubsub.PostEvent) bool {
+		return e.Kind == pubsub.PostCreated && e.Post.UserID == userUUID.String()
+	}), nil
+}
+
+// FeedUpdated handles subscribing to the posts created or updated in the current user's feed.
+// Who the user follows is read when subscribing.
+func (c *postsClient) FeedUpdated(ctx context.Context) (<-chan *model.PartialPost, error) {
+	follows, err := c.currentUserFollows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.subscribe(ctx, func(e pubsub.PostEvent) bool {
+		return (e.Kind == pubsub.PostCreated || e.Kind == pubsub.PostUpdated) && follows.includes(e)
+	}), nil
+}
+
+// PostDeleted handles subscribing to the posts deleted from the current user's feed or
+// by the current user. Who the user follows is read when subscribing.
+func (c *postsClient) PostDeleted(ctx context.Context) (<-chan *model.PartialPost, error) {
+	follows, err := c.currentUserFollows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.subscribe(ctx, func(e pubsub.PostEvent) bool {
+		return e.Kind == pubsub.PostDeleted && (e.Post.UserID == follows.userID || follows.includes(e))
+	}), nil
+}
+
+// subscribe sends the posts of the matching events until the context is done, the
+// bus closes or the subscriber falls too far behind
+func (c *postsClient) subscribe(ctx context.Context, match func(pubsub.PostEvent) bool) <-chan *model.PartialPost {
+	events := c.bus.Subscribe(ctx)
+	posts := make(chan *model.PartialPost)
+	go func() {
+		defer close(posts)
+		for e := range events {
+			if !match(e) {
+				continue
+			}
+			select {
+			case posts <- e.Post:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if c.bus.Closed() {
+			c.logger.Ctx(ctx).Debug("ending subscription as the bus has closed")
+			return
+		}
+		c.logger.Ctx(ctx).Warn("ending subscription that fell behind")
+	}()
+	return posts
+}
+
+// publish publishes what happened to the post
+func (c *postsClient) publish(ctx context.Context, kind pubsub.PostEventKind, post *sharedpb.Post, link *sharedpb.Link) {
+	partpost, postErr := model.PBPostToPartialPost(post, link)
+	if postErr != nil {
+		c.logger.Ctx(ctx).Warn("failed to publish post event", zap.String("error", *postErr.Message))
+		return
+	}
+	event := pubsub.PostEvent{Kind: kind, Post: partpost}
+	if link != nil {
+		if partlink, linkErr := model.PBLinkToPartialLink(link); linkErr == nil {
+			event.SourceIDs = partlink.SourceIDs
+		}
+	}
+	c.bus.Publish(event)
+}
+
+// follows is who a user follows, for matching post events
+type follows struct {
+	userID  string
+	users   map[string]bool
+	sources map[string]bool
+}
+
+// includes reports whether the post of the event is by a followed user or cites a followed source
+func (f follows) includes(e pubsub.PostEvent) bool {
+	if f.users[e.Post.UserID] {
+		return true
+	}
+	for _, id := range e.SourceIDs {
+		if f.sources[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// currentUserFollows reads who the current user follows
+func (c *postsClient) currentUserFollows(ctx context.Context) (follows, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == nil {
+		return follows{}, errors.New("no user found")
+	}
+	userUUIDs, sourceUUIDs, err := c.followed(ctx, userUUID)
+	if err != nil {
+		return follows{}, errors.Wrap(err, "failed to get who the current user follows")
+	}
+	f := follows{
+		userID:  uuid.FromBytesOrNil(userUUID).String(),
+		users:   make(map[string]bool, len(userUUIDs)),
+		sources: make(map[string]bool, len(sourceUUIDs)),
+	}
+	for _, u := range userUUIDs {
+		f.users[uuid.FromBytesOrNil(u).String()] = true
+	}
+	for _, s := range sourceUUIDs {
+		f.sources[uuid.FromBytesOrNil(s).String()] = true
+	}
+	return f, nil
+}
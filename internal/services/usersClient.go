@@ -3,52 +3,123 @@ package services
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	"github.com/srcabl/gateway/graph/model"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/csrf"
+	"github.com/srcabl/gateway/internal/dataloader"
+	"github.com/srcabl/gateway/internal/lockout"
+	"github.com/srcabl/gateway/internal/logging"
+	"github.com/srcabl/gateway/internal/mail"
+	"github.com/srcabl/gateway/internal/middleware"
+	"github.com/srcabl/gateway/internal/oidc"
+	"github.com/srcabl/gateway/internal/signed"
+	"github.com/srcabl/gateway/internal/token"
 	"github.com/srcabl/gateway/internal/util"
+	sharedpb "github.com/srcabl/protos/shared"
 	userspb "github.com/srcabl/protos/users"
-	"github.com/srcabl/services/pkg/config"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// invalidCredentialsMessage is the same whether or not the username or email exists
+	invalidCredentialsMessage = "username, email or password is incorrect"
+	// tooManyFailuresMessage is the same whether or not the username or email exists
+	tooManyFailuresMessage = "too many failed logins, try again later"
+	// resetPurpose is signed into password reset tokens, so no other token can reset a password
+	resetPurpose = "password reset"
 )
 
 // UsersClient defines the behavior of a users client
 type UsersClient interface {
-	Run() (func() error, error)
+	Run() (func(context.Context) error, error)
+	Health(context.Context) Health
+	Service() userspb.UsersServiceClient
 	//grapql handlers
 	CurrentUser(context.Context) (*model.CommonUserResponse, error)
+	CurrentUserUsersFollowed(context.Context) (*model.CommonUsersResponse, error)
+	CurrentUserSourcesFollowed(context.Context) (*model.CommonSourcesResponse, error)
+	Followers(context.Context, model.FollowersRequest) (*model.CommonUsersResponse, error)
 	ChangePassword(context.Context, model.ChangePasswordRequest) (*model.CommonUserResponse, error)
 	ForgotPassword(context.Context, string) (bool, error)
 	Register(context.Context, model.RegisterUserRequest) (*model.CommonUserResponse, error)
 	Login(context.Context, model.LoginUserRequest) (*model.CommonUserResponse, error)
 	Logout(context.Context) (bool, error)
+	CSRFToken(context.Context) (string, error)
+	CreateAccessToken(context.Context) (*model.AccessTokenResponse, error)
+	RefreshAccessToken(context.Context, string) (*model.AccessTokenResponse, error)
+	SignInWithIdentity(context.Context, oidc.Identity) error
 	FollowUser(context.Context, model.FollowRequest) (bool, error)
 	UnfollowUser(context.Context, model.FollowRequest) (bool, error)
 	FollowSource(context.Context, model.FollowRequest) (bool, error)
 	UnfollowSource(context.Context, model.FollowRequest) (bool, error)
+	UserByID(context.Context, string) (*model.PartialUser, error)
+	//dataloader fetchers
+	GetUsersByID(context.Context, []string) ([]*model.PartialUser, []error)
 }
 
 type usersClient struct {
-	usersPort   int
-	usersConn   *grpc.ClientConn
-	usersClient userspb.UsersServiceClient
+	logger        *logging.Logger
+	dialer        *Dialer
+	usersUpstream config.Upstream
+	usersConn     *grpc.ClientConn
+	usersClient   userspb.UsersServiceClient
+
+	sourcesClient SourcesClient
+
+	mailer      mail.Mailer
+	mailFrom    string
+	resetURL    string
+	resetTokens *signed.Tokens
+
+	security   *logging.Logger
+	lockout    *lockout.Tracker
+	trustProxy bool
+	csrf       *csrf.Protector
+	tokens     *token.Tokens
 }
 
 // NewUsersClient news up the users client
-func NewUsersClient(config *config.Gateway) (UsersClient, error) {
+func NewUsersClient(config *config.Gateway, logger *logging.Logger, dialer *Dialer, sourcesClient SourcesClient, mailer mail.Mailer, tokens *token.Tokens) (UsersClient, error) {
+	resetStore, err := signed.NewStore(config.PasswordReset.Store, config.PasswordReset.Dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up the password reset token store")
+	}
+	resetTokens, err := signed.NewTokens([]byte(config.PasswordReset.Secret), resetPurpose, config.PasswordReset.TTL, resetStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up password reset tokens")
+	}
+	tracker, err := lockout.NewTracker(config.Lockout, lockout.NewMemoryStore())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new up login lockout")
+	}
 	return &usersClient{
-		usersPort: config.Services.UsersPort,
+		logger:        logger.Named("users"),
+		dialer:        dialer,
+		usersUpstream: config.Upstreams.Users,
+		sourcesClient: sourcesClient,
+		mailer:        mailer,
+		mailFrom:      config.Mailer.From,
+		resetURL:      config.PasswordReset.URL,
+		resetTokens:   resetTokens,
+		security:      logger.Named("security"),
+		lockout:       tracker,
+		trustProxy:    config.RateLimits.TrustProxy,
+		csrf:          csrf.New(config.CSRF, config.Session.Secure),
+		tokens:        tokens,
 	}, nil
 }
 
 // Run starts up the clients
-func (c *usersClient) Run() (func() error, error) {
-	log.Printf("Starting Users Client Connection on: %d\n", c.usersPort)
-	usersConn, err := grpc.Dial(fmt.Sprintf("localhost:%d", c.usersPort), grpc.WithInsecure())
+func (c *usersClient) Run() (func(context.Context) error, error) {
+	usersConn, err := c.dialer.dial("users", c.usersUpstream)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to dial to users port: %d", c.usersPort)
+		return nil, errors.Wrap(err, "failed to dial to users")
 	}
 	c.usersConn = usersConn
 	c.usersClient = userspb.NewUsersServiceClient(usersConn)
@@ -56,9 +127,19 @@ func (c *usersClient) Run() (func() error, error) {
 	return c.close(), nil
 }
 
+// Service gets the grpc users service client
+func (c *usersClient) Service() userspb.UsersServiceClient {
+	return c.usersClient
+}
+
+// Health checks the health of the users service
+func (c *usersClient) Health(ctx context.Context) Health {
+	return checkHealth(ctx, "users", c.usersConn)
+}
+
 // Close closes the grpc connection
-func (c *usersClient) close() func() error {
-	return func() error {
+func (c *usersClient) close() func(context.Context) error {
+	return func(ctx context.Context) error {
 		if err := c.usersConn.Close(); err != nil {
 			return errors.Wrap(err, "failed to close users connection")
 		}
@@ -68,23 +149,76 @@ func (c *usersClient) close() func() error {
 
 // ChangePassword handles change password requests
 func (c *usersClient) ChangePassword(ctx context.Context, input model.ChangePasswordRequest) (*model.CommonUserResponse, error) {
-	//TODO
-	field := ""
-	message := "not implemented"
-	return &model.CommonUserResponse{
-		Errors: []*model.Error{
-			{
-				Field:   &field,
-				Message: &message,
-			},
-		},
-	}, nil
+	userUUID, err := c.resetTokens.Check(input.Token)
+	if err != nil {
+		return resetTokenErrorResponse(err)
+	}
+	userReq, commonErr := model.ValidateChangePasswordAndChangePasswordRequestToPBUpdatePasswordRequest(input, userUUID)
+	if commonErr != nil {
+		return &model.CommonUserResponse{
+			Errors: commonErr,
+		}, nil
+	}
+	res, resErr := c.usersClient.UpdatePassword(ctx, userReq)
+	if resErr == nil {
+		// use the token up only once the password has changed, so a failed change can be retried
+		if _, err := c.resetTokens.Consume(input.Token); err != nil && err != signed.ErrUsedToken {
+			return nil, errors.Wrap(err, "failed to use up reset token")
+		}
+		// any other outstanding tokens are no good once the password has changed
+		if err := c.resetTokens.Revoke(userUUID); err != nil {
+			return nil, errors.Wrap(err, "failed to revoke reset tokens")
+		}
+		// nor are refresh tokens issued before it changed
+		if err := c.tokens.Revoke(userUUID); err != nil {
+			return nil, errors.Wrap(err, "failed to revoke refresh tokens")
+		}
+		// nor are sessions signed in with the old password
+		if err := middleware.RevokeUserSessions(ctx, userUUID); err != nil {
+			return nil, errors.Wrap(err, "failed to revoke sessions")
+		}
+	}
+	userRes := model.PBUpdatePasswordResponseToCommonUserResponse(res, resErr)
+	return userRes, nil
 }
 
 // ForgotPassword handles forgot passowrd requests
 func (c *usersClient) ForgotPassword(ctx context.Context, email string) (bool, error) {
-	//TODO
-	return false, nil
+	if isvalid, _ := util.ValidateEmailRequirements(email); !isvalid {
+		return false, nil
+	}
+	userReq := model.EmailToPBGetUserRequest(email)
+	res, err := c.usersClient.GetUser(ctx, userReq)
+	if err != nil || res.User == nil {
+		// don't let on whether the email belongs to a user
+		return true, nil
+	}
+	token, err := c.resetTokens.Issue(res.User.Uuid)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to issue reset token")
+	}
+	msg := mail.Message{
+		From:    c.mailFrom,
+		To:      res.User.Email,
+		Subject: "Reset your srcabl password",
+		Body:    fmt.Sprintf("Hi %s,\n\nFollow this link to reset your password:\n%s\n", res.User.Username, fmt.Sprintf(c.resetURL, token)),
+	}
+	if err := c.mailer.Send(ctx, msg); err != nil {
+		return false, errors.Wrap(err, "failed to send reset email")
+	}
+	return true, nil
+}
+
+// resetTokenErrorResponse turns a bad reset token into a field error
+func resetTokenErrorResponse(err error) (*model.CommonUserResponse, error) {
+	switch err {
+	case signed.ErrInvalidToken, signed.ErrExpiredToken, signed.ErrUsedToken:
+		return &model.CommonUserResponse{
+			Errors: []*model.Error{model.NewFieldError("token", err.Error())},
+		}, nil
+	default:
+		return nil, errors.Wrap(err, "failed to check reset token")
+	}
 }
 
 // Register handles user register requests
@@ -100,34 +234,157 @@ func (c *usersClient) Register(ctx context.Context, input model.RegisterUserRequ
 		return nil, errors.Wrap(err, "failed to create user")
 	}
 	// set the user id for the session
-	util.SetUserUUIDToContext(ctx, createRes.User.Uuid)
+	if err := util.SetUserUUIDToContext(ctx, createRes.User.Uuid); err != nil {
+		return nil, errors.Wrap(err, "failed to sign in")
+	}
 	//get the user
 	userRes := model.PBCreateUserResponseToCommonUserResponse(createRes, err)
 	return userRes, nil
 }
 
-//Login handles login requests
+//Login handles login requests. Failed logins are tracked for the account and the
+//client ip, delaying and then locking out further attempts.
 func (c *usersClient) Login(ctx context.Context, input model.LoginUserRequest) (*model.CommonUserResponse, error) {
 	userReq, err := model.LoginUserRequestToPBValidateUserCredentials(input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to transform graph request to grpc request: %+v", err)
 	}
-	fmt.Printf("UserReq: %+v", userReq)
+	ip := c.clientIP(ctx)
+	account := c.lockoutAccount(ctx, userReq)
+	attempt, checkErr := c.lockout.Check(account, ip)
+	if checkErr != nil {
+		return nil, errors.Wrap(checkErr, "failed to check failed logins")
+	}
+	if !attempt.Allowed() {
+		c.logger.Ctx(ctx).Info("refused login", zap.Bool("locked", attempt.Locked), zap.Duration("retry_after", attempt.RetryAfter))
+		return &model.CommonUserResponse{
+			Errors: []*model.Error{model.NewFieldError("usernameOrEmail", tooManyFailuresMessage)},
+		}, nil
+	}
+	c.logger.Ctx(ctx).Debug("validating user credentials", zap.String("username", userReq.Username), zap.String("email", userReq.Email))
 	res, resErr := c.usersClient.ValidateUserCredentials(ctx, userReq)
+	if resErr != nil {
+		if !isCredentialsFailure(resErr) {
+			c.logger.Ctx(ctx).Warn("failed to validate user credentials", zap.Error(resErr))
+			return model.PBValidateUserResponseToCommonUserResponse(res, resErr), nil
+		}
+		c.logger.Ctx(ctx).Info("failed login", zap.Error(resErr))
+		lockouts, failErr := c.lockout.Fail(account, ip)
+		if failErr != nil {
+			c.logger.Ctx(ctx).Error("failed to record failed login", zap.Error(failErr))
+		}
+		for _, l := range lockouts {
+			c.security.Ctx(ctx).Warn("login locked out",
+				zap.String("event", "login_lockout"),
+				zap.String("kind", l.Kind),
+				zap.String("key", l.Key),
+				zap.String("client_ip", ip),
+				zap.Int("failures", l.Failures),
+				zap.Time("until", l.Until),
+			)
+		}
+		return &model.CommonUserResponse{
+			Errors: []*model.Error{model.NewFieldError("usernameOrEmail", invalidCredentialsMessage)},
+		}, nil
+	}
+	if err := c.lockout.Succeed(account); err != nil {
+		c.logger.Ctx(ctx).Error("failed to forget failed logins", zap.Error(err))
+	}
 	if res != nil && res.User != nil {
-		util.SetUserUUIDToContext(ctx, res.User.Uuid)
+		if err := util.SetUserUUIDToContext(ctx, res.User.Uuid); err != nil {
+			return nil, errors.Wrap(err, "failed to sign in")
+		}
 	}
-	userRes := model.PBValidateUserResponseToCommonUserResponse(res, resErr)
-	fmt.Printf("UserRes: %+v", userRes)
-	return userRes, nil
+	return model.PBValidateUserResponseToCommonUserResponse(res, resErr), nil
+}
+
+// clientIP gets the ip the request came from, if it came over http
+func (c *usersClient) clientIP(ctx context.Context) string {
+	httpContext, ok := middleware.GetHTTP(ctx)
+	if !ok {
+		return ""
+	}
+	return middleware.ClientIP(httpContext.R, c.trustProxy)
+}
+
+// lockoutAccount gets the account failed logins are counted against. Logins by email
+// count against the username of the user with the email, so that an account's failures
+// are counted together whichever way it is logged in to.
+func (c *usersClient) lockoutAccount(ctx context.Context, userReq *userspb.ValidateUserCredentialsRequest) string {
+	if userReq.ValidateUserBy != userspb.ValidateUserCredentialsRequest_EMAIL {
+		return userReq.Username
+	}
+	res, err := c.usersClient.GetUser(ctx, model.EmailToPBGetUserRequest(userReq.Email))
+	if err != nil && status.Code(err) != codes.NotFound {
+		c.logger.Ctx(ctx).Warn("failed to get user to count failed logins against", zap.Error(err))
+	}
+	if err != nil || res.User == nil {
+		return userReq.Email
+	}
+	return res.User.Username
+}
+
+// isCredentialsFailure reports whether the users service rejected the credentials,
+// rather than failing to check them at all
+func isCredentialsFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.NotFound, codes.InvalidArgument:
+		return true
+	}
+	return false
 }
 
 //Logout handles logout requests
 func (c *usersClient) Logout(ctx context.Context) (bool, error) {
-	util.SetUserUUIDToContext(ctx, nil)
+	if userUUID := util.GetTokenUserUUIDFromContext(ctx); userUUID != nil {
+		if err := c.tokens.Revoke(userUUID); err != nil {
+			return false, errors.Wrap(err, "failed to revoke refresh tokens")
+		}
+	}
+	if err := util.EndSession(ctx); err != nil {
+		return false, errors.Wrap(err, "failed to end session")
+	}
 	return true, nil
 }
 
+// CreateAccessToken handles issuing bearer tokens to the current user
+func (c *usersClient) CreateAccessToken(ctx context.Context) (*model.AccessTokenResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == nil {
+		return &model.AccessTokenResponse{
+			Errors: []*model.Error{model.NewFieldError("", "not logged in")},
+		}, nil
+	}
+	pair, err := c.tokens.Issue(userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to issue bearer tokens")
+	}
+	return model.TokenPairToAccessTokenResponse(pair), nil
+}
+
+// RefreshAccessToken handles trading a refresh token for new bearer tokens
+func (c *usersClient) RefreshAccessToken(ctx context.Context, refreshToken string) (*model.AccessTokenResponse, error) {
+	pair, err := c.tokens.Refresh(refreshToken)
+	if err == token.ErrUsedToken {
+		c.security.Ctx(ctx).Warn("refresh token reused, revoked the user's refresh tokens", zap.String("event", "refresh_token_reuse"))
+	}
+	switch err {
+	case nil:
+		return model.TokenPairToAccessTokenResponse(pair), nil
+	case token.ErrInvalidToken, token.ErrExpiredToken, token.ErrUsedToken:
+		return &model.AccessTokenResponse{
+			Errors: []*model.Error{model.NewFieldError("refreshToken", err.Error())},
+		}, nil
+	default:
+		return nil, errors.Wrap(err, "failed to refresh bearer tokens")
+	}
+}
+
+// CSRFToken handles requests for the token mutations must send back in the csrf header
+func (c *usersClient) CSRFToken(ctx context.Context) (string, error) {
+	return c.csrf.Token(ctx)
+}
+
 //CurrentUser handles current user requests
 func (c *usersClient) CurrentUser(ctx context.Context) (*model.CommonUserResponse, error) {
 	userUUID := util.GetUserUUIDFromContext(ctx)
@@ -140,6 +397,51 @@ func (c *usersClient) CurrentUser(ctx context.Context) (*model.CommonUserRespons
 	return userRes, nil
 }
 
+// CurrentUserUsersFollowed handles requests for the users the current user follows
+func (c *usersClient) CurrentUserUsersFollowed(ctx context.Context) (*model.CommonUsersResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == nil {
+		return nil, errors.New("current user does not exist")
+	}
+	followedReq := model.UserUUIDToPBListFollowedUsersRequest(userUUID)
+	res, resErr := c.usersClient.ListFollowedUsers(ctx, followedReq)
+	return model.PBListFollowedUsersResponseToCommonUsersResponse(res, resErr), nil
+}
+
+// CurrentUserSourcesFollowed handles requests for the sources the current user follows
+func (c *usersClient) CurrentUserSourcesFollowed(ctx context.Context) (*model.CommonSourcesResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == nil {
+		return nil, errors.New("current user does not exist")
+	}
+	followedReq := model.UserUUIDToPBListFollowedSourcesRequest(userUUID)
+	followedRes, err := c.usersClient.ListFollowedSources(ctx, followedReq)
+	if err != nil {
+		return &model.CommonSourcesResponse{
+			Errors: []*model.Error{model.PBResponseErrorToError(err)},
+		}, nil
+	}
+	if len(followedRes.SourceUuids) == 0 {
+		return &model.CommonSourcesResponse{}, nil
+	}
+	sourcesReq := model.SourceUUIDsToPBGetSourcesRequest(followedRes.SourceUuids)
+	res, resErr := c.sourcesClient.Service().GetSources(ctx, sourcesReq)
+	return model.PBGetSourcesResponseToCommonSourcesResponse(res, resErr), nil
+}
+
+// Followers handles requests for the followers of a user
+func (c *usersClient) Followers(ctx context.Context, input model.FollowersRequest) (*model.CommonUsersResponse, error) {
+	userUUID, err := uuid.FromString(input.UserID)
+	if err != nil {
+		return &model.CommonUsersResponse{
+			Errors: []*model.Error{model.NewFieldError("userID", "user id is not valid")},
+		}, nil
+	}
+	followersReq := model.UserUUIDToPBListFollowersRequest(userUUID.Bytes())
+	res, resErr := c.usersClient.ListFollowers(ctx, followersReq)
+	return model.PBListFollowersResponseToCommonUsersResponse(res, resErr), nil
+}
+
 func (c *usersClient) FollowUser(ctx context.Context, input model.FollowRequest) (bool, error) {
 	return c.performFollowReq(ctx, input, c.usersClient.Follow, userspb.FollowRequest_USER)
 }
@@ -174,3 +476,46 @@ func (c *usersClient) performFollowReq(ctx context.Context, input model.FollowRe
 	}
 	return true, nil
 }
+
+// UserByID handles resolving a user through the request's dataloader
+func (c *usersClient) UserByID(ctx context.Context, id string) (*model.PartialUser, error) {
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := loaders.UsersByID.Load(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load user %s", id)
+	}
+	return user, nil
+}
+
+// GetUsersByID fetches the users with the given ids in a single call, in the order of the ids
+func (c *usersClient) GetUsersByID(ctx context.Context, ids []string) ([]*model.PartialUser, []error) {
+	keys := parseLoaderKeys(ids)
+	users := make([]*model.PartialUser, len(ids))
+	if len(keys.valid) == 0 {
+		return users, keys.errs
+	}
+	res, err := c.usersClient.GetUsers(ctx, model.UserUUIDsToPBGetUsersRequest(keys.valid))
+	if err != nil {
+		return nil, []error{errors.Wrap(err, "failed to get users")}
+	}
+	byUUID := make(map[string]*sharedpb.User, len(res.Users))
+	for _, u := range res.Users {
+		byUUID[string(u.Uuid)] = u
+	}
+	for i, u := range keys.uuids {
+		user, ok := byUUID[string(u)]
+		if u == nil || !ok {
+			continue
+		}
+		partuser, userErr := model.PBUserToPartialUser(user)
+		if userErr != nil {
+			keys.setErr(i, userErr)
+			continue
+		}
+		users[i] = partuser
+	}
+	return users, keys.errs
+}
@@ -0,0 +1,148 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	"github.com/srcabl/gateway/internal/diskstore"
+)
+
+// Backend keeps the encoded values of server side sessions by their id, along with
+// the user signed in to each so all of a user's sessions can be deleted at once
+type Backend interface {
+	// Load loads the session, nil if it does not exist or has expired
+	Load(id string) ([]byte, error)
+	// Save saves the session, user is empty when nobody is signed in to it
+	Save(id, user string, data []byte, expires time.Time) error
+	Delete(id string) error
+	// DeleteUser deletes every session the user is signed in to
+	DeleteUser(user string) error
+}
+
+type storedSession struct {
+	user    string
+	data    []byte
+	expires time.Time
+}
+
+type memoryBackend struct {
+	mu       sync.Mutex
+	sessions map[string]storedSession
+	// users indexes the ids of each user's sessions
+	users map[string]map[string]bool
+	now   func() time.Time
+}
+
+// NewMemoryBackend news up an in memory session backend
+func NewMemoryBackend() Backend {
+	return &memoryBackend{
+		sessions: map[string]storedSession{},
+		users:    map[string]map[string]bool{},
+		now:      time.Now,
+	}
+}
+
+// Load loads the session, nil if it does not exist or has expired
+func (b *memoryBackend) Load(id string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s, ok := b.sessions[id]
+	if !ok || !b.now().Before(s.expires) {
+		return nil, nil
+	}
+	return s.data, nil
+}
+
+// Save saves the session, clearing out any that have expired
+func (b *memoryBackend) Save(id, user string, data []byte, expires time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	for k, s := range b.sessions {
+		if !now.Before(s.expires) {
+			b.delete(k)
+		}
+	}
+	b.delete(id)
+	b.sessions[id] = storedSession{user: user, data: data, expires: expires}
+	if user != "" {
+		if b.users[user] == nil {
+			b.users[user] = map[string]bool{}
+		}
+		b.users[user][id] = true
+	}
+	return nil
+}
+
+// Delete deletes the session
+func (b *memoryBackend) Delete(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.delete(id)
+	return nil
+}
+
+// DeleteUser deletes every session the user is signed in to
+func (b *memoryBackend) DeleteUser(user string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for id := range b.users[user] {
+		b.delete(id)
+	}
+	return nil
+}
+
+// delete deletes the session and its entry in the user index, the lock must be held
+func (b *memoryBackend) delete(id string) {
+	s, ok := b.sessions[id]
+	if !ok {
+		return
+	}
+	delete(b.sessions, id)
+	if ids := b.users[s.user]; ids != nil {
+		delete(ids, id)
+		if len(ids) == 0 {
+			delete(b.users, s.user)
+		}
+	}
+}
+
+// fileBackend keeps each session in its own file, along with when it expires and the
+// user signed in to it. Deleting a user's sessions reads through every file.
+type fileBackend struct {
+	files *diskstore.Dir
+}
+
+// NewFileBackend news up a session backend keeping sessions in files in the directory
+func NewFileBackend(dir string) (Backend, error) {
+	files, err := diskstore.New(dir, "session", validID)
+	if err != nil {
+		return nil, err
+	}
+	return &fileBackend{files: files}, nil
+}
+
+// Load loads the session, nil if it does not exist or has expired
+func (b *fileBackend) Load(id string) ([]byte, error) {
+	r, ok, err := b.files.Read(id)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return r.Data, nil
+}
+
+// Save saves the session, clearing out any that have expired
+func (b *fileBackend) Save(id, user string, data []byte, expires time.Time) error {
+	return b.files.Write(id, diskstore.Record{Owner: user, Data: data, Expires: expires})
+}
+
+// Delete deletes the session
+func (b *fileBackend) Delete(id string) error {
+	_, err := b.files.Remove(id)
+	return err
+}
+
+// DeleteUser deletes every session the user is signed in to
+func (b *fileBackend) DeleteUser(user string) error {
+	return b.files.RemoveOwner(user)
+}
@@ -0,0 +1,127 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func backends(t *testing.T) map[string]Backend {
+	t.Helper()
+	file, err := NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileBackend() error = %v", err)
+	}
+	return map[string]Backend{
+		"memory": NewMemoryBackend(),
+		"file":   file,
+	}
+}
+
+func mustID(t *testing.T) string {
+	t.Helper()
+	id, err := newID()
+	if err != nil {
+		t.Fatalf("newID() error = %v", err)
+	}
+	return id
+}
+
+func load(t *testing.T, b Backend, id string) []byte {
+	t.Helper()
+	data, err := b.Load(id)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return data
+}
+
+func TestBackendSaveLoadDelete(t *testing.T) {
+	for name, b := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			id := mustID(t)
+			if data := load(t, b, id); data != nil {
+				t.Fatalf("Load() of a missing session = %q, want nil", data)
+			}
+			expires := time.Now().Add(time.Hour)
+			if err := b.Save(id, "alice", []byte("one"), expires); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if data := load(t, b, id); string(data) != "one" {
+				t.Errorf("Load() = %q, want one", data)
+			}
+			if err := b.Save(id, "alice", []byte("two"), expires); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if data := load(t, b, id); string(data) != "two" {
+				t.Errorf("Load() after saving again = %q, want two", data)
+			}
+			if err := b.Delete(id); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if data := load(t, b, id); data != nil {
+				t.Errorf("Load() after Delete() = %q, want nil", data)
+			}
+			if err := b.Delete(id); err != nil {
+				t.Errorf("Delete() of a missing session error = %v", err)
+			}
+		})
+	}
+}
+
+func TestBackendExpiry(t *testing.T) {
+	for name, b := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			id := mustID(t)
+			if err := b.Save(id, "", []byte("data"), time.Now().Add(-time.Second)); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if data := load(t, b, id); data != nil {
+				t.Errorf("Load() of an expired session = %q, want nil", data)
+			}
+		})
+	}
+}
+
+func TestBackendDeleteUser(t *testing.T) {
+	for name, b := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			expires := time.Now().Add(time.Hour)
+			alice1, alice2, bob, anon, moved := mustID(t), mustID(t), mustID(t), mustID(t), mustID(t)
+			for id, user := range map[string]string{alice1: "alice", alice2: "alice", bob: "bob", anon: "", moved: "alice"} {
+				if err := b.Save(id, user, []byte(id), expires); err != nil {
+					t.Fatalf("Save() error = %v", err)
+				}
+			}
+			// a session signed in to someone else is no longer alice's
+			if err := b.Save(moved, "bob", []byte(moved), expires); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if err := b.DeleteUser("alice"); err != nil {
+				t.Fatalf("DeleteUser() error = %v", err)
+			}
+			for id, want := range map[string]bool{alice1: false, alice2: false, bob: true, anon: true, moved: true} {
+				if got := load(t, b, id) != nil; got != want {
+					t.Errorf("session %s kept = %v, want %v", id, got, want)
+				}
+			}
+			if err := b.DeleteUser("nobody"); err != nil {
+				t.Errorf("DeleteUser() of a user without sessions error = %v", err)
+			}
+		})
+	}
+}
+
+func TestFileBackendRefusesBadIDs(t *testing.T) {
+	b, err := NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileBackend() error = %v", err)
+	}
+	for _, id := range []string{"", "../session", "short"} {
+		if err := b.Save(id, "", nil, time.Now().Add(time.Hour)); err == nil {
+			t.Errorf("Save(%q) succeeded, want an error", id)
+		}
+		if _, err := b.Load(id); err == nil {
+			t.Errorf("Load(%q) succeeded, want an error", id)
+		}
+	}
+}
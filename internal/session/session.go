@@ -0,0 +1,124 @@
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+)
+
+// NewStore news up the configured session store
+func NewStore(cfg config.Session) (sessions.Store, error) {
+	options, err := cookieOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	keyPairs, err := keyPairs(cfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+	switch cfg.Store {
+	case "cookie":
+		store := sessions.NewCookieStore(keyPairs...)
+		store.Options = options
+		store.MaxAge(options.MaxAge)
+		return store, nil
+	case "memory":
+		return newServerStore(keyPairs, options, NewMemoryBackend()), nil
+	case "file":
+		backend, err := NewFileBackend(cfg.Dir)
+		if err != nil {
+			return nil, err
+		}
+		return newServerStore(keyPairs, options, backend), nil
+	default:
+		return nil, errors.Errorf("%s is not a session store", cfg.Store)
+	}
+}
+
+// NewCodecs news up codecs that sign and encrypt other cookies with the session keys,
+// expiring them after maxAge seconds
+func NewCodecs(cfg config.Session, maxAge int) ([]securecookie.Codec, error) {
+	keyPairs, err := keyPairs(cfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+	return codecs(keyPairs, maxAge), nil
+}
+
+// cookieOptions gets the options for the session cookie
+func cookieOptions(cfg config.Session) (*sessions.Options, error) {
+	options := &sessions.Options{
+		Path:     "/",
+		Domain:   cfg.Domain,
+		MaxAge:   int(cfg.MaxAge.Seconds()),
+		Secure:   cfg.Secure,
+		HttpOnly: cfg.HTTPOnly == nil || *cfg.HTTPOnly,
+	}
+	if options.MaxAge <= 0 {
+		return nil, errors.New("sessions require a positive max age")
+	}
+	switch strings.ToLower(cfg.SameSite) {
+	case "lax":
+		options.SameSite = http.SameSiteLaxMode
+	case "strict":
+		options.SameSite = http.SameSiteStrictMode
+	case "none":
+		if !cfg.Secure {
+			return nil, errors.New("browsers only accept SameSite none cookies when they are secure")
+		}
+		options.SameSite = http.SameSiteNoneMode
+	default:
+		return nil, errors.Errorf("%s is not a SameSite mode", cfg.SameSite)
+	}
+	return options, nil
+}
+
+// keyPairs gets the auth and encryption key of each session key, in the order
+// securecookie takes them
+func keyPairs(keys []config.SessionKey) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("sessions require at least one key")
+	}
+	pairs := make([][]byte, 0, 2*len(keys))
+	for i, key := range keys {
+		if key.Auth == "" {
+			return nil, errors.Errorf("session key %d has no auth key", i)
+		}
+		encryption := []byte(key.Encryption)
+		if len(encryption) == 0 {
+			encryption = deriveEncryptionKey(key.Auth)
+		}
+		switch len(encryption) {
+		case 16, 24, 32:
+		default:
+			return nil, errors.Errorf("session key %d has an encryption key of %d bytes, it must be 16, 24 or 32", i, len(encryption))
+		}
+		pairs = append(pairs, []byte(key.Auth), encryption)
+	}
+	return pairs, nil
+}
+
+// deriveEncryptionKey derives a 32 byte encryption key from the auth key, so that
+// a key configured with only an auth key still encrypts the cookie
+func deriveEncryptionKey(auth string) []byte {
+	mac := hmac.New(sha256.New, []byte(auth))
+	mac.Write([]byte("session encryption"))
+	return mac.Sum(nil)
+}
+
+// codecs gets the codecs for the key pairs, expiring cookies after maxAge seconds
+func codecs(keyPairs [][]byte, maxAge int) []securecookie.Codec {
+	cs := securecookie.CodecsFromPairs(keyPairs...)
+	for _, c := range cs {
+		if c, ok := c.(*securecookie.SecureCookie); ok {
+			c.MaxAge(maxAge)
+		}
+	}
+	return cs
+}
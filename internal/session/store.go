@@ -0,0 +1,149 @@
+package session
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/gob"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+	"github.com/pkg/errors"
+)
+
+const (
+	// idLength is how many random bytes make a session id
+	idLength = 32
+	// UserKey is the session value holding the uuid of the user signed in to it
+	UserKey = "userUUID"
+)
+
+// idEncoding encodes session ids so they are safe to use as file names
+var idEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// serverStore keeps the values of each session in a backend and only its id, signed
+// and encrypted, in the cookie. Deleting a session from the backend revokes it.
+type serverStore struct {
+	codecs  []securecookie.Codec
+	options *sessions.Options
+	backend Backend
+	now     func() time.Time
+}
+
+func newServerStore(keyPairs [][]byte, options *sessions.Options, backend Backend) *serverStore {
+	return &serverStore{
+		codecs:  codecs(keyPairs, options.MaxAge),
+		options: options,
+		backend: backend,
+		now:     time.Now,
+	}
+}
+
+// Get gets the named session, cached for the rest of the request
+func (s *serverStore) Get(r *http.Request, name string) (*sessions.Session, error) {
+	return sessions.GetRegistry(r).Get(s, name)
+}
+
+// New loads the named session whose id the request's cookie holds. A new session is
+// returned when there is no cookie or its session has expired or been revoked.
+func (s *serverStore) New(r *http.Request, name string) (*sessions.Session, error) {
+	session := sessions.NewSession(s, name)
+	options := *s.options
+	session.Options = &options
+	session.IsNew = true
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+	var id string
+	if err := securecookie.DecodeMulti(name, cookie.Value, &id, s.codecs...); err != nil {
+		return session, errors.Wrap(err, "failed to decode session cookie")
+	}
+	data, err := s.backend.Load(id)
+	if err != nil {
+		return session, errors.Wrap(err, "failed to load session")
+	}
+	if data == nil {
+		return session, nil
+	}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&session.Values); err != nil {
+		return session, errors.Wrap(err, "failed to decode session")
+	}
+	session.ID = id
+	session.IsNew = false
+	return session, nil
+}
+
+// Save saves the session to the backend and its id to the cookie. A session with a
+// negative max age is deleted instead, revoking it.
+func (s *serverStore) Save(r *http.Request, w http.ResponseWriter, session *sessions.Session) error {
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			if err := s.backend.Delete(session.ID); err != nil {
+				return errors.Wrap(err, "failed to delete session")
+			}
+		}
+		http.SetCookie(w, sessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+	if session.ID == "" {
+		id, err := newID()
+		if err != nil {
+			return err
+		}
+		session.ID = id
+	}
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(session.Values); err != nil {
+		return errors.Wrap(err, "failed to encode session")
+	}
+	expires := s.now().Add(time.Duration(session.Options.MaxAge) * time.Second)
+	user, _ := session.Values[UserKey].([]byte)
+	if err := s.backend.Save(session.ID, hex.EncodeToString(user), data.Bytes(), expires); err != nil {
+		return errors.Wrap(err, "failed to save session")
+	}
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs...)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode session cookie")
+	}
+	http.SetCookie(w, sessions.NewCookie(session.Name(), encoded, session.Options))
+	return nil
+}
+
+// Regenerate deletes the session from the backend so that saving it gives it a new
+// id, and the old id can no longer be used
+func (s *serverStore) Regenerate(session *sessions.Session) error {
+	if session.ID == "" {
+		return nil
+	}
+	if err := s.backend.Delete(session.ID); err != nil {
+		return errors.Wrap(err, "failed to delete session")
+	}
+	session.ID = ""
+	return nil
+}
+
+// RevokeUser deletes every session the user is signed in to
+func (s *serverStore) RevokeUser(userUUID []byte) error {
+	if err := s.backend.DeleteUser(hex.EncodeToString(userUUID)); err != nil {
+		return errors.Wrap(err, "failed to delete user sessions")
+	}
+	return nil
+}
+
+func newID() (string, error) {
+	b := make([]byte, idLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate session id")
+	}
+	return idEncoding.EncodeToString(b), nil
+}
+
+// validID reports whether the id is one that could have been generated
+func validID(id string) bool {
+	b, err := idEncoding.DecodeString(id)
+	return err == nil && len(b) == idLength
+}
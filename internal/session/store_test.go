@@ -0,0 +1,103 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/sessions"
+	"github.com/srcabl/gateway/internal/config"
+)
+
+const cookieName = "session"
+
+func newTestStore(t *testing.T, backend Backend, keys ...config.SessionKey) *serverStore {
+	t.Helper()
+	pairs, err := keyPairs(keys)
+	if err != nil {
+		t.Fatalf("keyPairs() error = %v", err)
+	}
+	return newServerStore(pairs, &sessions.Options{Path: "/", MaxAge: 3600}, backend)
+}
+
+// saveSession saves a session holding the user and gets the request carrying its cookie
+func saveSession(t *testing.T, s *serverStore, user string) (*sessions.Session, *http.Request) {
+	t.Helper()
+	session, err := s.New(httptest.NewRequest(http.MethodGet, "/", nil), cookieName)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	session.Values[UserKey] = []byte(user)
+	w := httptest.NewRecorder()
+	if err := s.Save(nil, w, session); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, c := range w.Result().Cookies() {
+		r.AddCookie(c)
+	}
+	return session, r
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	s := newTestStore(t, NewMemoryBackend(), config.SessionKey{Auth: "auth"})
+	saved, r := saveSession(t, s, "alice")
+	got, err := s.New(r, cookieName)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got.IsNew || got.ID != saved.ID {
+		t.Fatalf("New() = session %q new %v, want %q", got.ID, got.IsNew, saved.ID)
+	}
+	if user, _ := got.Values[UserKey].([]byte); string(user) != "alice" {
+		t.Errorf("user = %q, want alice", user)
+	}
+}
+
+func TestStoreDecodesRotatedOutKeys(t *testing.T) {
+	backend := NewMemoryBackend()
+	oldKey := config.SessionKey{Auth: "old auth", Encryption: "0123456789abcdef"}
+	newKey := config.SessionKey{Auth: "new auth"}
+	saved, r := saveSession(t, newTestStore(t, backend, oldKey), "alice")
+
+	rotated := newTestStore(t, backend, newKey, oldKey)
+	got, err := rotated.New(r, cookieName)
+	if err != nil {
+		t.Fatalf("New() with the old key rotated out error = %v", err)
+	}
+	if got.IsNew || got.ID != saved.ID {
+		t.Errorf("New() = session %q new %v, want %q", got.ID, got.IsNew, saved.ID)
+	}
+
+	dropped := newTestStore(t, backend, newKey)
+	if got, err := dropped.New(r, cookieName); err == nil || !got.IsNew {
+		t.Errorf("New() with the old key dropped = new %v, error %v, want a new session and an error", got.IsNew, err)
+	}
+}
+
+func TestStoreRevokes(t *testing.T) {
+	s := newTestStore(t, NewMemoryBackend(), config.SessionKey{Auth: "auth"})
+	saved, r := saveSession(t, s, "alice")
+	_, other := saveSession(t, s, "bob")
+
+	if err := s.RevokeUser([]byte("alice")); err != nil {
+		t.Fatalf("RevokeUser() error = %v", err)
+	}
+	if got, err := s.New(r, cookieName); err != nil || !got.IsNew {
+		t.Errorf("New() of a revoked session = new %v, error %v, want a new session", got.IsNew, err)
+	}
+	if got, err := s.New(other, cookieName); err != nil || got.IsNew {
+		t.Errorf("New() of another user's session = new %v, error %v, want it kept", got.IsNew, err)
+	}
+
+	// saving with a negative max age deletes the session
+	_, r = saveSession(t, s, "alice")
+	saved, _ = s.New(r, cookieName)
+	saved.Options.MaxAge = -1
+	if err := s.Save(nil, httptest.NewRecorder(), saved); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got, err := s.New(r, cookieName); err != nil || !got.IsNew {
+		t.Errorf("New() of a deleted session = new %v, error %v, want a new session", got.IsNew, err)
+	}
+}
@@ -0,0 +1,51 @@
+package signed
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/srcabl/gateway/internal/diskstore"
+)
+
+// fileStore keeps each outstanding token in its own file, holding when it expires and
+// its user. Removing a token removes its file, so on a directory shared between
+// gateways a token is only ever used up once.
+type fileStore struct {
+	files *diskstore.Dir
+}
+
+// NewFileStore news up a token store keeping tokens in files in the directory
+func NewFileStore(dir string) (Store, error) {
+	files, err := diskstore.New(dir, "token", validID)
+	if err != nil {
+		return nil, err
+	}
+	return &fileStore{files: files}, nil
+}
+
+// Add adds an outstanding token, clearing out any that have expired
+func (s *fileStore) Add(id string, userUUID []byte, expires time.Time) error {
+	return s.files.Write(id, diskstore.Record{Owner: string(userUUID), Expires: expires})
+}
+
+// Exists reports whether the token is still outstanding
+func (s *fileStore) Exists(id string) (bool, error) {
+	_, ok, err := s.files.Read(id)
+	return ok, err
+}
+
+// Remove removes the token, reporting whether it was outstanding
+func (s *fileStore) Remove(id string) (bool, error) {
+	return s.files.Remove(id)
+}
+
+// RemoveUser removes every outstanding token for the user, reading through every file
+func (s *fileStore) RemoveUser(userUUID []byte) error {
+	return s.files.RemoveOwner(string(userUUID))
+}
+
+// validID reports whether the id is one that could have been generated
+func validID(id string) bool {
+	b, err := hex.DecodeString(id)
+	return err == nil && len(b) == idLength
+}
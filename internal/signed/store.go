@@ -0,0 +1,92 @@
+package signed
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Store keeps track of the tokens that are still outstanding, so they can be used up and revoked
+type Store interface {
+	Add(id string, userUUID []byte, expires time.Time) error
+	Exists(id string) (bool, error)
+	Remove(id string) (bool, error)
+	RemoveUser(userUUID []byte) error
+}
+
+// NewStore news up the named store, one of memory or file. The file store keeps tokens
+// in the directory, which gateways can share so tokens outlive restarts and work on any of them.
+func NewStore(kind, dir string) (Store, error) {
+	switch kind {
+	case "memory":
+		return NewMemoryStore(), nil
+	case "file":
+		return NewFileStore(dir)
+	default:
+		return nil, errors.Errorf("%s is not a token store", kind)
+	}
+}
+
+type storedToken struct {
+	userUUID []byte
+	expires  time.Time
+}
+
+type memoryStore struct {
+	mu     sync.Mutex
+	tokens map[string]storedToken
+	now    func() time.Time
+}
+
+// NewMemoryStore news up an in memory token store
+func NewMemoryStore() Store {
+	return &memoryStore{
+		tokens: map[string]storedToken{},
+		now:    time.Now,
+	}
+}
+
+// Add adds an outstanding token, clearing out any that have expired
+func (s *memoryStore) Add(id string, userUUID []byte, expires time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for k, t := range s.tokens {
+		if !now.Before(t.expires) {
+			delete(s.tokens, k)
+		}
+	}
+	s.tokens[id] = storedToken{userUUID: userUUID, expires: expires}
+	return nil
+}
+
+// Exists reports whether the token is still outstanding
+func (s *memoryStore) Exists(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.tokens[id]
+	return ok, nil
+}
+
+// Remove removes the token, reporting whether it was outstanding
+func (s *memoryStore) Remove(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.tokens[id]
+	delete(s.tokens, id)
+	return ok, nil
+}
+
+// RemoveUser removes every outstanding token for the user
+func (s *memoryStore) RemoveUser(userUUID []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, t := range s.tokens {
+		if bytes.Equal(t.userUUID, userUUID) {
+			delete(s.tokens, k)
+		}
+	}
+	return nil
+}
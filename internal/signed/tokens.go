@@ -0,0 +1,182 @@
+package signed
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	idLength     = 16
+	userLength   = 16
+	expiryLength = 8
+	payloadLen   = idLength + userLength + expiryLength
+)
+
+var (
+	// ErrInvalidToken is returned when a token is malformed or its signature does not match
+	ErrInvalidToken = errors.New("token is not valid")
+	// ErrExpiredToken is returned when a token is past its expiry
+	ErrExpiredToken = errors.New("token has expired")
+	// ErrUsedToken is returned when a token has already been used or was revoked
+	ErrUsedToken = errors.New("token has already been used")
+)
+
+// Tokens issues and checks signed, expiring tokens for users. The purpose is signed
+// along with each token, so tokens issued for one purpose are never valid for another
+// even when they share a key. Tokens are checked by their signature alone unless there
+// is a store, which tracks the outstanding ones so they can be used up and revoked.
+type Tokens struct {
+	key     []byte
+	purpose string
+	ttl     time.Duration
+	store   Store
+	now     func() time.Time
+}
+
+// NewTokens news up tokens for the purpose signed with the given key. The store may be
+// nil for tokens that are never used up or revoked.
+func NewTokens(key []byte, purpose string, ttl time.Duration, store Store) (*Tokens, error) {
+	if len(key) == 0 {
+		return nil, errors.Errorf("%s tokens require a signing key", purpose)
+	}
+	if purpose == "" {
+		return nil, errors.New("tokens require a purpose")
+	}
+	if ttl <= 0 {
+		return nil, errors.Errorf("%s tokens require a positive ttl", purpose)
+	}
+	return &Tokens{
+		key:     key,
+		purpose: purpose,
+		ttl:     ttl,
+		store:   store,
+		now:     time.Now,
+	}, nil
+}
+
+// TTL is how long issued tokens are valid for
+func (t *Tokens) TTL() time.Duration {
+	return t.ttl
+}
+
+// Issue issues a new token for the user
+func (t *Tokens) Issue(userUUID []byte) (string, error) {
+	if len(userUUID) != userLength {
+		return "", errors.New("user uuid is not valid")
+	}
+	payload := make([]byte, payloadLen)
+	if _, err := rand.Read(payload[:idLength]); err != nil {
+		return "", errors.Wrap(err, "failed to generate the token id")
+	}
+	copy(payload[idLength:], userUUID)
+	expires := t.now().Add(t.ttl)
+	binary.BigEndian.PutUint64(payload[idLength+userLength:], uint64(expires.Unix()))
+
+	if t.store != nil {
+		if err := t.store.Add(tokenID(payload), userUUID, expires); err != nil {
+			return "", errors.Wrap(err, "failed to store the token")
+		}
+	}
+	return base64.RawURLEncoding.EncodeToString(append(payload, t.sign(payload)...)), nil
+}
+
+// Authenticate checks the token's signature and expiry, returning the user it was issued
+// to. It does not check whether the token has been used up or revoked.
+func (t *Tokens) Authenticate(token string) ([]byte, error) {
+	payload, err := t.open(token)
+	if err != nil {
+		return nil, err
+	}
+	return userUUID(payload), nil
+}
+
+// Check checks the token is valid without using it up, returning the user it was issued to
+func (t *Tokens) Check(token string) ([]byte, error) {
+	payload, err := t.open(token)
+	if err != nil {
+		return nil, err
+	}
+	if t.store == nil {
+		return userUUID(payload), nil
+	}
+	exists, err := t.store.Exists(tokenID(payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to look up the token")
+	}
+	if !exists {
+		return nil, ErrUsedToken
+	}
+	return userUUID(payload), nil
+}
+
+// Consume checks the token and uses it up so it cannot be used again. A token that
+// has already been used returns ErrUsedToken along with the user it was issued to.
+func (t *Tokens) Consume(token string) ([]byte, error) {
+	payload, err := t.open(token)
+	if err != nil {
+		return nil, err
+	}
+	if t.store == nil {
+		return nil, errors.Errorf("%s tokens cannot be used up without a store", t.purpose)
+	}
+	removed, err := t.store.Remove(tokenID(payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to use the token")
+	}
+	if !removed {
+		return userUUID(payload), ErrUsedToken
+	}
+	return userUUID(payload), nil
+}
+
+// Revoke invalidates every outstanding token for the user
+func (t *Tokens) Revoke(userUUID []byte) error {
+	if t.store == nil {
+		return nil
+	}
+	if err := t.store.RemoveUser(userUUID); err != nil {
+		return errors.Wrap(err, "failed to revoke the user's tokens")
+	}
+	return nil
+}
+
+// open decodes the token and checks its signature and expiry
+func (t *Tokens) open(token string) ([]byte, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != payloadLen+sha256.Size {
+		return nil, ErrInvalidToken
+	}
+	payload, sig := raw[:payloadLen], raw[payloadLen:]
+	if !hmac.Equal(sig, t.sign(payload)) {
+		return nil, ErrInvalidToken
+	}
+	expires := time.Unix(int64(binary.BigEndian.Uint64(payload[idLength+userLength:])), 0)
+	if !t.now().Before(expires) {
+		return nil, ErrExpiredToken
+	}
+	return payload, nil
+}
+
+// sign signs the purpose, followed by a zero byte, and the payload
+func (t *Tokens) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte(t.purpose))
+	mac.Write([]byte{0})
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func tokenID(payload []byte) string {
+	return hex.EncodeToString(payload[:idLength])
+}
+
+func userUUID(payload []byte) []byte {
+	return append([]byte(nil), payload[idLength:idLength+userLength]...)
+}
@@ -0,0 +1,214 @@
+package signed
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	alice = bytes.Repeat([]byte{1}, userLength)
+	bob   = bytes.Repeat([]byte{2}, userLength)
+)
+
+// stores runs the test against each kind of store
+func stores(t *testing.T, test func(t *testing.T, store Store)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, NewMemoryStore())
+	})
+	t.Run("file", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewFileStore() error = %v", err)
+		}
+		test(t, store)
+	})
+}
+
+func newTokens(t *testing.T, purpose string, store Store) *Tokens {
+	t.Helper()
+	tokens, err := NewTokens([]byte("secret"), purpose, time.Hour, store)
+	if err != nil {
+		t.Fatalf("NewTokens() error = %v", err)
+	}
+	return tokens
+}
+
+func issue(t *testing.T, tokens *Tokens, user []byte) string {
+	t.Helper()
+	token, err := tokens.Issue(user)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	return token
+}
+
+// tamper flips a bit in the decoded token at the offset
+func tamper(token string, offset int) string {
+	raw, _ := base64.RawURLEncoding.DecodeString(token)
+	raw[offset] ^= 1
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func TestNewTokensRejectsBadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     []byte
+		purpose string
+		ttl     time.Duration
+	}{
+		{name: "no key", purpose: "reset", ttl: time.Hour},
+		{name: "no purpose", key: []byte("secret"), ttl: time.Hour},
+		{name: "no ttl", key: []byte("secret"), purpose: "reset"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTokens(tt.key, tt.purpose, tt.ttl, nil); err == nil {
+				t.Error("NewTokens() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tokens := newTokens(t, "reset", NewMemoryStore())
+	token := issue(t, tokens, alice)
+	other := newTokens(t, "access", NewMemoryStore())
+	otherKey, _ := NewTokens([]byte("other secret"), "reset", time.Hour, NewMemoryStore())
+
+	tests := []struct {
+		name    string
+		tokens  *Tokens
+		token   string
+		wantErr error
+	}{
+		{name: "valid", tokens: tokens, token: token},
+		{name: "not base64", tokens: tokens, token: "!!!", wantErr: ErrInvalidToken},
+		{name: "truncated", tokens: tokens, token: token[:len(token)-2], wantErr: ErrInvalidToken},
+		{name: "tampered user", tokens: tokens, token: tamper(token, idLength), wantErr: ErrInvalidToken},
+		{name: "tampered expiry", tokens: tokens, token: tamper(token, payloadLen-1), wantErr: ErrInvalidToken},
+		{name: "tampered signature", tokens: tokens, token: tamper(token, payloadLen), wantErr: ErrInvalidToken},
+		{name: "other purpose", tokens: other, token: token, wantErr: ErrInvalidToken},
+		{name: "other key", tokens: otherKey, token: token, wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := tt.tokens.Check(tt.token)
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !bytes.Equal(user, alice) {
+				t.Errorf("Check() user = %x, want %x", user, alice)
+			}
+		})
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	tokens := newTokens(t, "reset", NewMemoryStore())
+	issued := time.Now()
+	tokens.now = func() time.Time { return issued }
+	token := issue(t, tokens, alice)
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		wantErr error
+	}{
+		{name: "just issued", at: issued},
+		{name: "before expiry", at: issued.Add(time.Hour - time.Second)},
+		{name: "at expiry", at: issued.Add(time.Hour), wantErr: ErrExpiredToken},
+		{name: "after expiry", at: issued.Add(2 * time.Hour), wantErr: ErrExpiredToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens.now = func() time.Time { return tt.at }
+			if _, err := tokens.Check(token); errors.Cause(err) != tt.wantErr {
+				t.Errorf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := tokens.Authenticate(token); errors.Cause(err) != tt.wantErr {
+				t.Errorf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConsumeOnce(t *testing.T) {
+	stores(t, func(t *testing.T, store Store) {
+		tokens := newTokens(t, "reset", store)
+		token := issue(t, tokens, alice)
+
+		user, err := tokens.Consume(token)
+		if err != nil || !bytes.Equal(user, alice) {
+			t.Fatalf("Consume() = %x, %v, want %x", user, err, alice)
+		}
+		user, err = tokens.Consume(token)
+		if err != ErrUsedToken || !bytes.Equal(user, alice) {
+			t.Errorf("second Consume() = %x, %v, want %x with %v", user, err, alice, ErrUsedToken)
+		}
+		if _, err := tokens.Check(token); err != ErrUsedToken {
+			t.Errorf("Check() after Consume() error = %v, want %v", err, ErrUsedToken)
+		}
+		// authenticating only looks at the signature, so it still passes
+		if _, err := tokens.Authenticate(token); err != nil {
+			t.Errorf("Authenticate() after Consume() error = %v", err)
+		}
+	})
+}
+
+func TestConsumeSharedStore(t *testing.T) {
+	stores(t, func(t *testing.T, store Store) {
+		first, second := newTokens(t, "reset", store), newTokens(t, "reset", store)
+		token := issue(t, first, alice)
+		if _, err := second.Consume(token); err != nil {
+			t.Fatalf("Consume() on another gateway error = %v", err)
+		}
+		if _, err := first.Consume(token); err != ErrUsedToken {
+			t.Errorf("Consume() on the issuing gateway error = %v, want %v", err, ErrUsedToken)
+		}
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	stores(t, func(t *testing.T, store Store) {
+		tokens := newTokens(t, "reset", store)
+		aliceTokens := []string{issue(t, tokens, alice), issue(t, tokens, alice)}
+		bobToken := issue(t, tokens, bob)
+
+		if err := tokens.Revoke(alice); err != nil {
+			t.Fatalf("Revoke() error = %v", err)
+		}
+		for _, token := range aliceTokens {
+			if _, err := tokens.Check(token); err != ErrUsedToken {
+				t.Errorf("Check() of a revoked token error = %v, want %v", err, ErrUsedToken)
+			}
+		}
+		if _, err := tokens.Check(bobToken); err != nil {
+			t.Errorf("Check() of another user's token error = %v", err)
+		}
+	})
+}
+
+func TestWithoutStore(t *testing.T) {
+	tokens := newTokens(t, "access", nil)
+	token := issue(t, tokens, alice)
+	if _, err := tokens.Check(token); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+	if err := tokens.Revoke(alice); err != nil {
+		t.Errorf("Revoke() error = %v", err)
+	}
+	if _, err := tokens.Consume(token); err == nil {
+		t.Error("Consume() without a store succeeded, want an error")
+	}
+}
+
+func TestIssueRejectsBadUser(t *testing.T) {
+	tokens := newTokens(t, "reset", nil)
+	if _, err := tokens.Issue([]byte("short")); err == nil {
+		t.Error("Issue() succeeded, want an error")
+	}
+}
@@ -0,0 +1,39 @@
+package token
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/srcabl/gateway/internal/util"
+)
+
+// FromHeader gets the token from an Authorization header value, empty if it is not a bearer token
+func FromHeader(authorization string) string {
+	if len(authorization) > len(TypeBearer)+1 && strings.EqualFold(authorization[:len(TypeBearer)+1], TypeBearer+" ") {
+		return strings.TrimSpace(authorization[len(TypeBearer)+1:])
+	}
+	return ""
+}
+
+// InjectBearer handles authenticating requests that carry an access token in the
+// Authorization header, injecting the user into the context alongside the cookie
+// session. Requests with a token that is not valid are refused.
+func (t *Tokens) InjectBearer() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			access := FromHeader(r.Header.Get("Authorization"))
+			if access == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			userUUID, err := t.Authenticate(access)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`%s error="invalid_token", error_description=%q`, TypeBearer, err.Error()))
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(util.SetTokenUserUUIDToContext(r.Context(), userUUID)))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package token
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/signed"
+)
+
+const (
+	// TypeBearer is the type of every access token, as sent in the Authorization header
+	TypeBearer = "Bearer"
+
+	// accessPurpose and refreshPurpose are signed into access and refresh tokens, so
+	// one can never be used as the other
+	accessPurpose  = "access"
+	refreshPurpose = "refresh"
+)
+
+var (
+	// ErrInvalidToken is returned when a token is malformed, of the wrong kind or its signature does not match
+	ErrInvalidToken = signed.ErrInvalidToken
+	// ErrExpiredToken is returned when a token is past its expiry
+	ErrExpiredToken = signed.ErrExpiredToken
+	// ErrUsedToken is returned when a refresh token has already been used or was revoked
+	ErrUsedToken = signed.ErrUsedToken
+)
+
+// Pair is an access token and the refresh token that replaces it once it expires
+type Pair struct {
+	Access    string
+	Refresh   string
+	ExpiresIn time.Duration
+}
+
+// Tokens issues and checks signed bearer tokens. Access tokens are short lived and
+// checked by their signature alone. Refresh tokens are long lived and single use:
+// refreshing uses one up and issues a new pair.
+type Tokens struct {
+	access  *signed.Tokens
+	refresh *signed.Tokens
+}
+
+// NewTokens news up bearer tokens with the configured key and lifetimes, keeping the
+// outstanding refresh tokens in the store
+func NewTokens(cfg config.Tokens, store signed.Store) (*Tokens, error) {
+	access, err := signed.NewTokens([]byte(cfg.Secret), accessPurpose, cfg.AccessTTL, nil)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := signed.NewTokens([]byte(cfg.Secret), refreshPurpose, cfg.RefreshTTL, store)
+	if err != nil {
+		return nil, err
+	}
+	return &Tokens{
+		access:  access,
+		refresh: refresh,
+	}, nil
+}
+
+// Issue issues a new access and refresh token for the user
+func (t *Tokens) Issue(userUUID []byte) (*Pair, error) {
+	access, err := t.access.Issue(userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to issue access token")
+	}
+	refresh, err := t.refresh.Issue(userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to issue refresh token")
+	}
+	return &Pair{Access: access, Refresh: refresh, ExpiresIn: t.access.TTL()}, nil
+}
+
+// Authenticate checks the access token, returning the user it was issued to
+func (t *Tokens) Authenticate(access string) ([]byte, error) {
+	return t.access.Authenticate(access)
+}
+
+// Refresh uses up the refresh token and issues a new pair to its user. A refresh
+// token used twice has likely been stolen, so every outstanding one of the user's
+// is revoked.
+func (t *Tokens) Refresh(refresh string) (*Pair, error) {
+	userUUID, err := t.refresh.Consume(refresh)
+	if err == ErrUsedToken {
+		if err := t.Revoke(userUUID); err != nil {
+			return nil, err
+		}
+		return nil, ErrUsedToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t.Issue(userUUID)
+}
+
+// Revoke invalidates every outstanding refresh token for the user. Access tokens
+// already issued stay valid until they expire.
+func (t *Tokens) Revoke(userUUID []byte) error {
+	if err := t.refresh.Revoke(userUUID); err != nil {
+		return errors.Wrap(err, "failed to revoke the user's refresh tokens")
+	}
+	return nil
+}
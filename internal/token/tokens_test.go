@@ -0,0 +1,172 @@
+package token
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/srcabl/gateway/internal/config"
+	"github.com/srcabl/gateway/internal/signed"
+	"github.com/srcabl/gateway/internal/util"
+)
+
+var (
+	alice = bytes.Repeat([]byte{1}, 16)
+	bob   = bytes.Repeat([]byte{2}, 16)
+)
+
+func newTokens(t *testing.T) *Tokens {
+	t.Helper()
+	tokens, err := NewTokens(config.Tokens{
+		Secret:     "token secret",
+		AccessTTL:  time.Minute,
+		RefreshTTL: time.Hour,
+	}, signed.NewMemoryStore())
+	if err != nil {
+		t.Fatalf("NewTokens() error = %v", err)
+	}
+	return tokens
+}
+
+func issue(t *testing.T, tokens *Tokens, user []byte) *Pair {
+	t.Helper()
+	pair, err := tokens.Issue(user)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	return pair
+}
+
+func TestIssueAndAuthenticate(t *testing.T) {
+	tokens := newTokens(t)
+	pair := issue(t, tokens, alice)
+	if pair.ExpiresIn != time.Minute {
+		t.Errorf("ExpiresIn = %v, want the access ttl", pair.ExpiresIn)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "access token", token: pair.Access},
+		{name: "refresh token as access", token: pair.Refresh, wantErr: ErrInvalidToken},
+		{name: "garbage", token: "garbage", wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := tokens.Authenticate(tt.token)
+			if err != tt.wantErr {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !bytes.Equal(user, alice) {
+				t.Errorf("Authenticate() user = %x, want %x", user, alice)
+			}
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	tokens := newTokens(t)
+	pair := issue(t, tokens, alice)
+
+	if _, err := tokens.Refresh(pair.Access); err != ErrInvalidToken {
+		t.Errorf("Refresh() with an access token error = %v, want %v", err, ErrInvalidToken)
+	}
+	next, err := tokens.Refresh(pair.Refresh)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if next.Refresh == pair.Refresh {
+		t.Error("Refresh() did not rotate the refresh token")
+	}
+	if user, err := tokens.Authenticate(next.Access); err != nil || !bytes.Equal(user, alice) {
+		t.Errorf("Authenticate() of the refreshed access token = %x, %v, want %x", user, err, alice)
+	}
+}
+
+func TestRefreshReuseRevokesTheUser(t *testing.T) {
+	tokens := newTokens(t)
+	stolen := issue(t, tokens, alice)
+	other := issue(t, tokens, bob)
+
+	next, err := tokens.Refresh(stolen.Refresh)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if _, err := tokens.Refresh(stolen.Refresh); err != ErrUsedToken {
+		t.Fatalf("reusing a refresh token error = %v, want %v", err, ErrUsedToken)
+	}
+	if _, err := tokens.Refresh(next.Refresh); err != ErrUsedToken {
+		t.Errorf("Refresh() of the user's newer token error = %v, want %v after reuse", err, ErrUsedToken)
+	}
+	if _, err := tokens.Refresh(other.Refresh); err != nil {
+		t.Errorf("Refresh() of another user's token error = %v", err)
+	}
+}
+
+func TestFromHeader(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "Bearer abc", want: "abc"},
+		{header: "bearer abc ", want: "abc"},
+		{header: "BEARER abc", want: "abc"},
+		{header: "Bearer ", want: ""},
+		{header: "Bearer", want: ""},
+		{header: "Basic abc", want: ""},
+		{header: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := FromHeader(tt.header); got != tt.want {
+			t.Errorf("FromHeader(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestInjectBearer(t *testing.T) {
+	tokens := newTokens(t)
+	pair := issue(t, tokens, alice)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantCode      int
+		wantUser      []byte
+	}{
+		{name: "no header", wantCode: http.StatusOK},
+		{name: "other scheme", authorization: "Basic abc", wantCode: http.StatusOK},
+		{name: "access token", authorization: "Bearer " + pair.Access, wantCode: http.StatusOK, wantUser: alice},
+		{name: "refresh token", authorization: "Bearer " + pair.Refresh, wantCode: http.StatusUnauthorized},
+		{name: "bad token", authorization: "Bearer abc", wantCode: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var user []byte
+			handler := tokens.InjectBearer()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user = util.GetTokenUserUUIDFromContext(r.Context())
+			}))
+			req := httptest.NewRequest("POST", "/graphql", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if !bytes.Equal(user, tt.wantUser) {
+				t.Errorf("context user = %x, want %x", user, tt.wantUser)
+			}
+			challenge := rec.Header().Get("WWW-Authenticate")
+			if (tt.wantCode == http.StatusUnauthorized) != strings.HasPrefix(challenge, `Bearer error="invalid_token"`) {
+				t.Errorf("WWW-Authenticate = %q", challenge)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/srcabl/gateway/internal/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GraphQL returns the gqlgen extension tracing operations and resolvers
+func GraphQL() graphql.HandlerExtension {
+	return graphqlExtension{}
+}
+
+type graphqlExtension struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = graphqlExtension{}
+
+// ExtensionName names the extension
+func (e graphqlExtension) ExtensionName() string {
+	return "Tracing"
+}
+
+// Validate has nothing to validate against the schema
+func (e graphqlExtension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptResponse starts a server span covering each operation
+func (e graphqlExtension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	name, kind := "unknown", "unknown"
+	if graphql.HasOperationContext(ctx) {
+		rc := graphql.GetOperationContext(ctx)
+		if rc.Operation != nil {
+			kind = string(rc.Operation.Operation)
+		}
+		name = kind
+		if rc.OperationName != "" {
+			name = rc.OperationName
+		}
+	}
+	ctx, span := tracer().Start(ctx, fmt.Sprintf("graphql.%s", name),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("graphql.operation.name", name),
+			attribute.String("graphql.operation.type", kind),
+			attribute.String("request.id", middleware.GetRequestID(ctx)),
+		),
+	)
+	defer span.End()
+
+	res := next(ctx)
+	if res != nil && len(res.Errors) > 0 {
+		span.SetAttributes(attribute.Int("graphql.errors", len(res.Errors)))
+		span.SetStatus(codes.Error, res.Errors[0].Message)
+	}
+	return res
+}
+
+// InterceptField starts a child span for each resolver. Fields that are plain
+// struct reads are skipped as they cannot take any time.
+func (e graphqlExtension) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+
+	ctx, span := tracer().Start(ctx, fmt.Sprintf("%s.%s", fc.Object, fc.Field.Name),
+		trace.WithAttributes(
+			attribute.String("graphql.field.object", fc.Object),
+			attribute.String("graphql.field.name", fc.Field.Name),
+			attribute.String("graphql.field.alias", fc.Field.Alias),
+		),
+	)
+	defer span.End()
+
+	res, err := next(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return res, err
+}
@@ -0,0 +1,114 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/srcabl/gateway/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/exporters/stdout"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName names the tracer the gateway creates its spans with
+const instrumentationName = "github.com/srcabl/gateway"
+
+// Tracing defines the behavior of the gateway tracing
+type Tracing interface {
+	Run() (func(context.Context) error, error)
+}
+
+type tracing struct {
+	cfg      config.Tracing
+	provider *sdktrace.TracerProvider
+	file     io.Closer
+}
+
+// New news up the tracing configured for the gateway. The trace context
+// propagator is installed straight away so spans link up even when no
+// exporter is configured.
+func New(cfg config.Tracing) (Tracing, error) {
+	switch cfg.Exporter {
+	case "none", "stdout", "file", "otlp":
+	default:
+		return nil, errors.Errorf("unknown tracing exporter %s", cfg.Exporter)
+	}
+	if cfg.Exporter == "file" && cfg.Path == "" {
+		return nil, errors.New("file tracing exporter requires a path")
+	}
+	if cfg.Exporter == "otlp" && cfg.Endpoint == "" {
+		return nil, errors.New("otlp tracing exporter requires an endpoint")
+	}
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	return &tracing{cfg: cfg}, nil
+}
+
+// Run installs the tracer provider and returns a closer that flushes any spans left
+func (t *tracing) Run() (func(context.Context) error, error) {
+	if t.cfg.Exporter == "none" {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := t.exporter()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to new up the %s tracing exporter", t.cfg.Exporter)
+	}
+	t.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(t.cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(t.cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(t.provider)
+	return t.close(), nil
+}
+
+func (t *tracing) exporter() (sdktrace.SpanExporter, error) {
+	switch t.cfg.Exporter {
+	case "stdout":
+		return stdout.NewExporter(stdout.WithoutMetricExport(), stdout.WithPrettyPrint())
+	case "file":
+		f, err := os.OpenFile(t.cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open trace file %s", t.cfg.Path)
+		}
+		t.file = f
+		return stdout.NewExporter(stdout.WithoutMetricExport(), stdout.WithWriter(f))
+	default:
+		opts := []otlpgrpc.Option{otlpgrpc.WithEndpoint(t.cfg.Endpoint)}
+		if t.cfg.Insecure {
+			opts = append(opts, otlpgrpc.WithInsecure())
+		}
+		return otlp.NewExporter(context.Background(), otlpgrpc.NewDriver(opts...))
+	}
+}
+
+// close flushes the spans still batched and closes the exporter
+func (t *tracing) close() func(context.Context) error {
+	return func(ctx context.Context) error {
+		if err := t.provider.Shutdown(ctx); err != nil {
+			return errors.Wrap(err, "failed to shut down the tracer provider")
+		}
+		if t.file != nil {
+			if err := t.file.Close(); err != nil {
+				return errors.Wrap(err, "failed to close the trace file")
+			}
+		}
+		return nil
+	}
+}
+
+// tracer returns the gateway tracer from the installed provider, looked up on
+// every use so spans started before Run are no-ops rather than lost
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
@@ -0,0 +1,135 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/srcabl/gateway/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	remoteTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	remoteSpanID  = "00f067aa0ba902b7"
+)
+
+// record installs the gateway propagator and a tracer provider exporting into memory
+func record(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	if _, err := New(config.Tracing{Exporter: "none"}); err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return exporter
+}
+
+// call runs a unary call through the interceptor and returns the metadata the upstream would see
+func call(ctx context.Context, err error) (metadata.MD, error) {
+	var sent metadata.MD
+	callErr := UnaryClientInterceptor()(ctx, "/users.UsersService/GetUser", nil, nil, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			sent, _ = metadata.FromOutgoingContext(ctx)
+			return err
+		})
+	return sent, callErr
+}
+
+func TestTraceContextPropagatesToUpstreams(t *testing.T) {
+	exporter := record(t)
+
+	var sent metadata.MD
+	handler := InjectTraceContext()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := metadata.AppendToOutgoingContext(r.Context(), "x-request-id", "abc")
+		var err error
+		if sent, err = call(ctx, nil); err != nil {
+			t.Errorf("call error = %v", err)
+		}
+	}))
+	req := httptest.NewRequest("POST", "/graphql", nil)
+	req.Header.Set("traceparent", "00-"+remoteTraceID+"-"+remoteSpanID+"-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "users.UsersService/GetUser" || span.SpanKind != trace.SpanKindClient {
+		t.Errorf("span = %s (%v), want a client span for users.UsersService/GetUser", span.Name, span.SpanKind)
+	}
+	if got := span.SpanContext.TraceID().String(); got != remoteTraceID {
+		t.Errorf("span trace id = %s, want the caller's %s", got, remoteTraceID)
+	}
+	if got := span.Parent.SpanID().String(); got != remoteSpanID || !span.Parent.IsRemote() {
+		t.Errorf("span parent = %s, want the caller's remote span %s", got, remoteSpanID)
+	}
+
+	want := "00-" + remoteTraceID + "-" + span.SpanContext.SpanID().String() + "-01"
+	if got := sent.Get("traceparent"); len(got) != 1 || got[0] != want {
+		t.Errorf("upstream traceparent = %v, want %s", got, want)
+	}
+	if got := sent.Get("x-request-id"); len(got) != 1 || got[0] != "abc" {
+		t.Errorf("upstream lost the existing metadata, x-request-id = %v", got)
+	}
+}
+
+func TestUpstreamErrorsMarkTheSpan(t *testing.T) {
+	exporter := record(t)
+
+	if _, err := call(context.Background(), status.Error(grpccodes.NotFound, "no user")); err == nil {
+		t.Fatal("call succeeded, want the upstream error")
+	}
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Parent.IsValid() {
+		t.Errorf("span parent = %v, want a root span without a caller trace", span.Parent)
+	}
+	if span.StatusCode != codes.Error || !strings.Contains(span.StatusMessage, "no user") {
+		t.Errorf("span status = %v %q, want an error", span.StatusCode, span.StatusMessage)
+	}
+	var code string
+	for _, attr := range span.Attributes {
+		if attr.Key == "rpc.grpc.status_code" {
+			code = attr.Value.AsString()
+		}
+	}
+	if code != "NotFound" {
+		t.Errorf("span status code attribute = %q, want NotFound", code)
+	}
+}
+
+func TestNewRejectsBadConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Tracing
+	}{
+		{name: "unknown exporter", cfg: config.Tracing{Exporter: "jaeger"}},
+		{name: "file without path", cfg: config.Tracing{Exporter: "file"}},
+		{name: "otlp without endpoint", cfg: config.Tracing{Exporter: "otlp"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.cfg); err == nil {
+				t.Error("New() succeeded, want an error")
+			}
+		})
+	}
+}
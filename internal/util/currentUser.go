@@ -1,14 +1,35 @@
 package util
 
 import (
+	"bytes"
 	"context"
 
+	"github.com/pkg/errors"
 	"github.com/srcabl/gateway/internal/middleware"
+	"github.com/srcabl/gateway/internal/session"
 )
 
+// tokenUserKey is the context key of the user authenticated by a bearer token
+type tokenUserKey struct{}
+
+// SetTokenUserUUIDToContext sets the user authenticated by a bearer token, who takes
+// the place of any user in the cookie session
+func SetTokenUserUUIDToContext(ctx context.Context, userUUID []byte) context.Context {
+	return context.WithValue(ctx, tokenUserKey{}, userUUID)
+}
+
+// GetTokenUserUUIDFromContext gets the user authenticated by a bearer token, if any
+func GetTokenUserUUIDFromContext(ctx context.Context) []byte {
+	userUUID, _ := ctx.Value(tokenUserKey{}).([]byte)
+	return userUUID
+}
+
 func GetUserUUIDFromContext(ctx context.Context) []byte {
-	session := middleware.GetSession(ctx, "uid")
-	userID := session.Values["userUUID"]
+	if userUUID := GetTokenUserUUIDFromContext(ctx); userUUID != nil {
+		return userUUID
+	}
+	sess := middleware.GetSession(ctx, "uid")
+	userID := sess.Values[session.UserKey]
 	if userID == nil {
 		return nil
 	}
@@ -16,9 +37,21 @@ func GetUserUUIDFromContext(ctx context.Context) []byte {
 	return userUUID
 }
 
-func SetUserUUIDToContext(ctx context.Context, userUUID []byte) {
-	session := middleware.GetSession(ctx, "uid")
-	session.Values["userUUID"] = userUUID
-	middleware.SaveSession(ctx, session)
+// SetUserUUIDToContext signs the user in to the session. The session gets a new id
+// whenever its user changes, so an id planted before signing in is no good after.
+func SetUserUUIDToContext(ctx context.Context, userUUID []byte) error {
+	sess := middleware.GetSession(ctx, "uid")
+	if current, _ := sess.Values[session.UserKey].([]byte); !bytes.Equal(current, userUUID) {
+		if err := middleware.RegenerateSession(ctx, sess); err != nil {
+			return errors.Wrap(err, "failed to regenerate session")
+		}
+	}
+	sess.Values[session.UserKey] = userUUID
+	return middleware.SaveSession(ctx, sess)
+}
 
+// EndSession logs the user out by destroying their session
+func EndSession(ctx context.Context) error {
+	sess := middleware.GetSession(ctx, "uid")
+	return middleware.DestroySession(ctx, sess)
 }
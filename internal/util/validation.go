@@ -2,9 +2,20 @@ package util
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"strings"
 )
 
+// privateNetworks are the ip ranges a link may not point into
+var privateNetworks = mustParseCIDRs(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"100.64.0.0/10",
+	"fc00::/7",
+)
+
 // ValidateUsernameRequirements validates username requirements
 func ValidateUsernameRequirements(username string) (bool, string) {
 	if strings.Contains(username, "@") {
@@ -34,3 +45,54 @@ func ValidateEmailRequirements(testEmail string) (bool, string) {
 	}
 	return true, ""
 }
+
+// ValidateLinkURLRequirements validates that a url is a web link to a public host,
+// so the services fetching it are not pointed at the internal network
+func ValidateLinkURLRequirements(rawURL string) (bool, string) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false, "url is not valid"
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false, "url must be http or https"
+	}
+	if u.User != nil {
+		return false, "url must not have credentials"
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "" {
+		return false, "url must have a host"
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false, "url must be a public host"
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if !ip.IsGlobalUnicast() || isPrivateIP(ip) {
+			return false, "url must be a public host"
+		}
+	} else if !strings.Contains(host, ".") {
+		return false, "url must be a public host"
+	}
+	return true, ""
+}
+
+func isPrivateIP(ip net.IP) bool {
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}